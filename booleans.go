@@ -46,7 +46,7 @@ func DoesBboxOverlap(b1 *BoundingBox, b2 *BoundingBox) (bool, error) {
 // IsPointOnLine returns true if a point is on a line.
 // Accepts a parameter to ignore the start and end vertices of the linestring.
 func IsPointOnLine(point Point, lineString *LineString, ignoreEnds bool) bool {
-	points := lineString.getPoints()
+	points := lineString.GetPoints()
 	for i := 0; i < len(points)-1; i++ {
 		excludeBoundary := BoundaryNone
 		if ignoreEnds {
@@ -67,6 +67,104 @@ func IsPointOnLine(point Point, lineString *LineString, ignoreEnds bool) bool {
 	return false
 }
 
+// IsPointOnLineWithin returns true if a point is closer than tolerance, in the given unit, to a line. Distances
+// are measured on the sphere whatever the earth model, with the segments of the line following great circles, so
+// that the closest point of a segment and its distance to the point agree. It also returns the index
+// of the closest segment and the distance along the line to the closest point of this segment, to snap the
+// point on the line. If ignoreEnds is true, points closer than tolerance to the first or the last point of the
+// line are not on it.
+func IsPointOnLineWithin(point *Point, lineString *LineString, tolerance float64, unit Unit, ignoreEnds bool) (segment int, offset float64, ok bool) {
+	points := lineString.GetPoints()
+	if len(points) < 2 {
+		return -1, 0, false
+	}
+	if ignoreEnds && (haversineDistance(point, points[0], unit) <= tolerance || haversineDistance(point, points[len(points)-1], unit) <= tolerance) {
+		return -1, 0, false
+	}
+	segment, closest := -1, math.Inf(1)
+	travelled := float64(0)
+	for i := 0; i < len(points)-1; i++ {
+		nearest := nearestOnSegment(point, points[i], points[i+1])
+		if distance := haversineDistance(point, nearest, unit); distance <= tolerance && distance < closest {
+			segment, closest = i, distance
+			offset = travelled + haversineDistance(points[i], nearest, unit)
+		}
+		travelled += haversineDistance(points[i], points[i+1], unit)
+	}
+	if segment == -1 {
+		return -1, 0, false
+	}
+	return segment, offset, true
+}
+
+// nearestOnSegment returns the point of the great circle arc from start to end closest to point, at the along
+// track distance of the point
+func nearestOnSegment(point, start, end *Point) *Point {
+	length := haversineDistance(start, end, Radians)
+	if length == 0 {
+		return start
+	}
+	distance := haversineDistance(start, point, Radians)
+	bearing := sphericalBearing(start, end)
+	angle := DegreeToRads(sphericalBearing(start, point) - bearing)
+	alongTrack := math.Atan2(math.Sin(distance)*math.Cos(angle), math.Cos(distance))
+	if alongTrack <= 0 {
+		return start
+	}
+	if alongTrack >= length {
+		return end
+	}
+	return sphericalDestination(start, alongTrack, bearing, Radians)
+}
+
+// IsClockwise returns true if a ring is clockwise, with longitudes as x and latitudes as y. The ring is closed
+// if its last point is not its first.
+func IsClockwise(ring *LineString) bool {
+	points := ring.GetPoints()
+	sum := float64(0)
+	for i := range points {
+		previous, point := points[(i+len(points)-1)%len(points)], points[i]
+		sum += (point.Lng - previous.Lng) * (point.Lat + previous.Lat)
+	}
+	return sum > 0
+}
+
+// IsConvex returns true if a polygon is convex: it has no hole and its outer ring turns always the same way,
+// once around. Points lying on the line through their neighbours are ignored.
+func IsConvex(polygon *Polygon) bool {
+	rings, _ := planarRings(polygon)
+	return len(polygon.LineStrings) == 1 && len(rings) == 1 && isConvexRing(rings[0])
+}
+
+// IsConcave returns true if the outer ring of a polygon is not convex, holes are ignored. A polygon whose
+// outer ring has less than 3 distinct points is neither convex nor concave.
+func IsConcave(polygon *Polygon) bool {
+	if len(polygon.LineStrings) == 0 {
+		return false
+	}
+	rings, _ := planarRings(NewPolygon(polygon.LineStrings[:1]))
+	return len(rings) == 1 && !isConvexRing(rings[0])
+}
+
+func isConvexRing(ring []vec) bool {
+	sign, turning := float64(0), float64(0)
+	for i := range ring {
+		a, b, c := ring[i], ring[(i+1)%len(ring)], ring[(i+2)%len(ring)]
+		d1, d2 := b.sub(a), c.sub(b)
+		turn := cross(d1, d2)
+		turning += math.Atan2(turn, dot(d1, d2))
+		if turn == 0 {
+			continue
+		}
+		if sign*turn < 0 {
+			return false
+		}
+		sign = turn
+	}
+	// a star turns the same way at every vertex but goes around more than once
+	return sign != 0 && math.Abs(math.Abs(turning)-2*math.Pi) < 1e-9
+}
+
 func isPointOnLineSegment(start Point, end Point, point Point, excludeBoundary Boundary) bool {
 	dxc := point.Lng - start.Lng
 	dyc := point.Lat - start.Lat
@@ -124,3 +222,124 @@ func isPointOnLineSegment(start Point, end Point, point Point, excludeBoundary B
 		return end.Lat < point.Lat && point.Lat < end.Lat
 	}
 }
+
+// IntersectionMatrix is a DE-9IM matrix, the dimensions of the intersections of the interior, the boundary and
+// the exterior of a geometry with the interior, the boundary and the exterior of another, in this order. The
+// dimension is -1 for an empty intersection, 0 for points, 1 for lines and 2 for areas.
+type IntersectionMatrix [3][3]int
+
+// String returns the matrix in the usual form, like "212101212", with F for empty intersections
+func (m IntersectionMatrix) String() string {
+	s := make([]byte, 0, 9)
+	for _, row := range m {
+		for _, dimension := range row {
+			if dimension < 0 {
+				s = append(s, 'F')
+			} else {
+				s = append(s, byte('0'+dimension))
+			}
+		}
+	}
+	return string(s)
+}
+
+// Matches tells if the matrix matches a pattern of 9 characters: T for a non empty intersection, F for an empty
+// one, 0, 1 or 2 for a dimension and * for any value.
+func (m IntersectionMatrix) Matches(pattern string) bool {
+	if len(pattern) != 9 {
+		return false
+	}
+	for i, c := range pattern {
+		dimension := m[i/3][i%3]
+		switch c {
+		case '*':
+		case 'T', 't':
+			if dimension < 0 {
+				return false
+			}
+		case 'F', 'f':
+			if dimension >= 0 {
+				return false
+			}
+		case '0', '1', '2':
+			if dimension != int(c-'0') {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Relate returns the DE-9IM intersection matrix of two geometries. Coordinates are taken as planar, like Inside
+// does, and points closer than a tiny fraction of the size of the geometries are considered equal. The parts of
+// collections, features and multi geometries are merged into one geometry, the boundary of lines follows the
+// mod 2 rule: only end points shared by an odd number of lines are on the boundary.
+func Relate(a, b Geometry) IntersectionMatrix {
+	return relate(newRelateGeometry(a), newRelateGeometry(b))
+}
+
+// Equals returns true if two geometries cover the same points, whatever their vertices
+func Equals(a, b Geometry) bool {
+	return Relate(a, b).Matches("T*F**FFF*")
+}
+
+// Disjoint returns true if two geometries have no point in common
+func Disjoint(a, b Geometry) bool {
+	return Relate(a, b).Matches("FF*FF****")
+}
+
+// Intersects returns true if two geometries have at least one point in common
+func Intersects(a, b Geometry) bool {
+	return !Disjoint(a, b)
+}
+
+// Touches returns true if two geometries have points in common, but only on their boundaries
+func Touches(a, b Geometry) bool {
+	m := Relate(a, b)
+	return m.Matches("FT*******") || m.Matches("F**T*****") || m.Matches("F***T****")
+}
+
+// Contains returns true if no point of b is outside a and at least one point of the interior of b is in the
+// interior of a
+func Contains(a, b Geometry) bool {
+	return Relate(a, b).Matches("T*****FF*")
+}
+
+// IsWithin returns true if a is inside b, it is Contains with the geometries swapped. See Within for points
+// within polygons.
+func IsWithin(a, b Geometry) bool {
+	return Relate(a, b).Matches("T*F**F***")
+}
+
+// Crosses returns true if the interiors of two geometries meet in a geometry of lower dimension than the
+// largest of them, and each of them has points outside the other: a line through a polygon, lines crossing
+// at a point or points partly in a line.
+func Crosses(a, b Geometry) bool {
+	ga, gb := newRelateGeometry(a), newRelateGeometry(b)
+	m := relate(ga, gb)
+	switch {
+	case ga.dimension < gb.dimension:
+		return m.Matches("T*T******")
+	case ga.dimension > gb.dimension:
+		return m.Matches("T*****T**")
+	case ga.dimension == 1:
+		return m.Matches("0********")
+	}
+	return false
+}
+
+// Overlaps returns true if two geometries of the same dimension share part of their interiors, of the same
+// dimension, and each of them has points outside the other
+func Overlaps(a, b Geometry) bool {
+	ga, gb := newRelateGeometry(a), newRelateGeometry(b)
+	m := relate(ga, gb)
+	switch {
+	case ga.dimension != gb.dimension:
+		return false
+	case ga.dimension == 1:
+		return m.Matches("1*T***T**")
+	}
+	return m.Matches("T*T***T**")
+}
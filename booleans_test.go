@@ -4,6 +4,7 @@ import (
 	"github.com/kpawlik/geojson"
 	. "github.com/smartystreets/goconvey/convey"
 	"io/ioutil"
+	"math"
 	"testing"
 )
 
@@ -117,3 +118,281 @@ func TestIsPointOnLine(t *testing.T) {
 	})
 
 }
+
+// xyLine creates a lineString from x, y pairs
+func xyLine(coordinates ...float64) *LineString {
+	points := []*Point{}
+	for i := 0; i+1 < len(coordinates); i += 2 {
+		points = append(points, NewPoint(coordinates[i+1], coordinates[i]))
+	}
+	return NewLineString(points)
+}
+
+func xyPoint(x, y float64) *Point {
+	return NewPoint(y, x)
+}
+
+func TestRelate(t *testing.T) {
+	square := box(0, 0, 10, 10)
+	withHole := NewPolygon([]*LineString{NewLineString(square.LineStrings[0].Points), xyLine(2, 2, 2, 8, 8, 8, 8, 2, 2, 2)})
+
+	Convey("Given two polygons, should return their intersection matrix", t, func() {
+		So(Relate(square, box(5, 5, 15, 15)).String(), ShouldEqual, "212101212")
+		So(Relate(square, box(2, 2, 8, 8)).String(), ShouldEqual, "212FF1FF2")
+		So(Relate(box(2, 2, 8, 8), square).String(), ShouldEqual, "2FF1FF212")
+		So(Relate(square, box(0, 0, 5, 5)).String(), ShouldEqual, "212F11FF2")
+		So(Relate(square, box(10, 0, 20, 10)).String(), ShouldEqual, "FF2F11212")
+		So(Relate(square, box(10, 10, 20, 20)).String(), ShouldEqual, "FF2F01212")
+		So(Relate(square, box(20, 20, 30, 30)).String(), ShouldEqual, "FF2FF1212")
+		So(Relate(square, box(3, 3, 7, 7)).String(), ShouldEqual, "212FF1FF2")
+		So(Relate(withHole, box(3, 3, 7, 7)).String(), ShouldEqual, "FF2FF1212")
+		rotated := NewPolygon([]*LineString{xyLine(10, 10, 0, 10, 0, 5, 0, 0, 10, 0, 10, 10)})
+		So(Relate(square, rotated).String(), ShouldEqual, "2FFF1FFF2")
+	})
+
+	Convey("Given a line and a polygon, should return their intersection matrix", t, func() {
+		So(Relate(xyLine(-5, 5, 15, 5), square).String(), ShouldEqual, "101FF0212")
+		So(Relate(xyLine(2, 5, 8, 5), square).String(), ShouldEqual, "1FF0FF212")
+		So(Relate(xyLine(0, 0, 10, 0), square).String(), ShouldEqual, "F1FF0F212")
+		So(Relate(xyLine(5, 5, 5, 15), square).String(), ShouldEqual, "1010F0212")
+		So(Relate(xyLine(4, 5, 6, 5), withHole).String(), ShouldEqual, "FF1FF0212")
+	})
+
+	Convey("Given two lines, should return their intersection matrix", t, func() {
+		So(Relate(xyLine(0, 0, 10, 10), xyLine(0, 10, 10, 0)).String(), ShouldEqual, "0F1FF0102")
+		So(Relate(xyLine(0, 0, 10, 0), xyLine(5, 0, 15, 0)).String(), ShouldEqual, "1010F0102")
+		So(Relate(xyLine(0, 0, 10, 0), xyLine(10, 0, 10, 10)).String(), ShouldEqual, "FF1F00102")
+		So(Relate(xyLine(0, 0, 10, 0), xyLine(0, 0, 5, 0, 10, 0)).String(), ShouldEqual, "1FFF0FFF2")
+		So(Relate(xyLine(0, 0, 10, 0), xyLine(0, 5, 10, 5)).String(), ShouldEqual, "FF1FF0102")
+	})
+
+	Convey("Given points, should return their intersection matrix", t, func() {
+		So(Relate(xyPoint(5, 5), square).String(), ShouldEqual, "0FFFFF212")
+		So(Relate(xyPoint(10, 5), square).String(), ShouldEqual, "F0FFFF212")
+		So(Relate(xyPoint(20, 5), square).String(), ShouldEqual, "FF0FFF212")
+		So(Relate(xyPoint(5, 5), withHole).String(), ShouldEqual, "FF0FFF212")
+		So(Relate(xyPoint(5, 0), xyLine(0, 0, 10, 0)).String(), ShouldEqual, "0FFFFF102")
+		So(Relate(xyPoint(0, 0), xyLine(0, 0, 10, 0)).String(), ShouldEqual, "F0FFFF102")
+		So(Relate(NewMultiPoint([]*Point{xyPoint(0, 0), xyPoint(1, 1)}), xyPoint(0, 0)).String(), ShouldEqual, "0F0FFFFF2")
+		So(Relate(xyPoint(1, 1), xyPoint(1, 1)).String(), ShouldEqual, "0FFFFFFF2")
+	})
+
+	Convey("Given lines sharing end points, the boundary should follow the mod 2 rule", t, func() {
+		closed := xyLine(0, 0, 10, 0, 10, 10, 0, 0)
+		So(Relate(xyPoint(0, 0), closed).String(), ShouldEqual, "0FFFFF1F2")
+		joined := NewMultiLineString([]*LineString{xyLine(0, 0, 5, 0), xyLine(5, 0, 10, 0)})
+		So(Relate(xyPoint(5, 0), joined).String(), ShouldEqual, "0FFFFF102")
+		So(Relate(xyPoint(10, 0), joined).String(), ShouldEqual, "F0FFFF102")
+	})
+
+	Convey("Given collections, features and empty geometries, should merge their parts", t, func() {
+		collection := NewGeometryCollection([]Geometry{box(0, 0, 5, 10), box(5, 0, 10, 10), xyPoint(20, 20)})
+		So(Relate(collection, box(1, 1, 2, 2)).String(), ShouldEqual, "212FF1FF2")
+		feature := NewFeature(square, nil)
+		So(Relate(NewFeatureCollection([]*Feature{feature}), xyLine(-5, 5, 15, 5)).String(), ShouldEqual, "1F20F1102")
+		So(Relate(NewMultiPoint([]*Point{}), square).String(), ShouldEqual, "FFFFFF212")
+		So(Relate(NewFeature(nil, nil), NewLineString(nil)).String(), ShouldEqual, "FFFFFFFF2")
+	})
+
+	Convey("Given a matrix, should match patterns", t, func() {
+		m := Relate(square, box(5, 5, 15, 15))
+		So(m, ShouldResemble, IntersectionMatrix{{2, 1, 2}, {1, 0, 1}, {2, 1, 2}})
+		So(m.Matches("T*T***T**"), ShouldBeTrue)
+		So(m.Matches("212101212"), ShouldBeTrue)
+		So(m.Matches("FF*FF****"), ShouldBeFalse)
+		So(m.Matches("2121012"), ShouldBeFalse)
+		So(m.Matches("21210121x"), ShouldBeFalse)
+	})
+}
+
+func TestPredicates(t *testing.T) {
+	square := box(0, 0, 10, 10)
+
+	Convey("Given geometries, should tell if they are equal", t, func() {
+		So(Equals(square, NewPolygon([]*LineString{xyLine(10, 10, 0, 10, 0, 5, 0, 0, 10, 0, 10, 10)})), ShouldBeTrue)
+		So(Equals(xyLine(0, 0, 10, 0), xyLine(10, 0, 5, 0, 0, 0)), ShouldBeTrue)
+		So(Equals(square, box(0, 0, 10, 11)), ShouldBeFalse)
+		So(Equals(xyPoint(1, 1), NewMultiPoint([]*Point{xyPoint(1, 1), xyPoint(1, 1)})), ShouldBeTrue)
+	})
+
+	Convey("Given geometries, should tell if they are disjoint or intersect", t, func() {
+		So(Disjoint(square, box(20, 20, 30, 30)), ShouldBeTrue)
+		So(Intersects(square, box(20, 20, 30, 30)), ShouldBeFalse)
+		So(Disjoint(square, box(10, 10, 30, 30)), ShouldBeFalse)
+		So(Intersects(square, xyPoint(10, 3)), ShouldBeTrue)
+		So(Intersects(xyLine(0, 0, 10, 10), xyLine(0, 10, 10, 0)), ShouldBeTrue)
+	})
+
+	Convey("Given geometries, should tell if they touch", t, func() {
+		So(Touches(square, box(10, 0, 20, 10)), ShouldBeTrue)
+		So(Touches(square, box(10, 10, 20, 20)), ShouldBeTrue)
+		So(Touches(square, box(5, 5, 20, 20)), ShouldBeFalse)
+		So(Touches(xyPoint(10, 5), square), ShouldBeTrue)
+		So(Touches(xyLine(0, 0, 10, 0), xyLine(10, 0, 10, 10)), ShouldBeTrue)
+		So(Touches(xyPoint(1, 1), xyPoint(1, 1)), ShouldBeFalse)
+	})
+
+	Convey("Given geometries, should tell if one contains the other", t, func() {
+		So(Contains(square, box(2, 2, 8, 8)), ShouldBeTrue)
+		So(Contains(square, box(0, 0, 5, 5)), ShouldBeTrue)
+		So(Contains(square, xyLine(0, 0, 10, 0)), ShouldBeFalse)
+		So(Contains(square, xyLine(2, 5, 8, 5)), ShouldBeTrue)
+		So(Contains(square, box(5, 5, 15, 15)), ShouldBeFalse)
+		So(IsWithin(box(2, 2, 8, 8), square), ShouldBeTrue)
+		So(IsWithin(xyPoint(5, 5), square), ShouldBeTrue)
+		So(IsWithin(xyPoint(10, 5), square), ShouldBeFalse)
+		So(IsWithin(square, box(2, 2, 8, 8)), ShouldBeFalse)
+	})
+
+	Convey("Given geometries, should tell if they cross", t, func() {
+		So(Crosses(xyLine(-5, 5, 15, 5), square), ShouldBeTrue)
+		So(Crosses(square, xyLine(-5, 5, 15, 5)), ShouldBeTrue)
+		So(Crosses(xyLine(2, 5, 8, 5), square), ShouldBeFalse)
+		So(Crosses(xyLine(0, 0, 10, 10), xyLine(0, 10, 10, 0)), ShouldBeTrue)
+		So(Crosses(xyLine(0, 0, 10, 0), xyLine(5, 0, 15, 0)), ShouldBeFalse)
+		So(Crosses(NewMultiPoint([]*Point{xyPoint(5, 5), xyPoint(20, 20)}), square), ShouldBeTrue)
+		So(Crosses(square, box(5, 5, 15, 15)), ShouldBeFalse)
+	})
+
+	Convey("Given geometries, should tell if they overlap", t, func() {
+		So(Overlaps(square, box(5, 5, 15, 15)), ShouldBeTrue)
+		So(Overlaps(square, box(2, 2, 8, 8)), ShouldBeFalse)
+		So(Overlaps(square, box(10, 0, 20, 10)), ShouldBeFalse)
+		So(Overlaps(xyLine(0, 0, 10, 0), xyLine(5, 0, 15, 0)), ShouldBeTrue)
+		So(Overlaps(xyLine(0, 0, 10, 10), xyLine(0, 10, 10, 0)), ShouldBeFalse)
+		So(Overlaps(NewMultiPoint([]*Point{xyPoint(0, 0), xyPoint(1, 1)}), NewMultiPoint([]*Point{xyPoint(1, 1), xyPoint(2, 2)})), ShouldBeTrue)
+		So(Overlaps(square, xyLine(-5, 5, 15, 5)), ShouldBeFalse)
+	})
+}
+
+func BenchmarkRelate(b *testing.B) {
+	circle1 := Buffer(NewPoint(0, 0), 100, Kilometers, 64)
+	circle2 := Buffer(NewPoint(0.5, 0.5), 100, Kilometers, 64)
+	for n := 0; n < b.N; n++ {
+		testResultB = Intersects(circle1, circle2)
+	}
+}
+
+func TestIsPointOnLineWithin(t *testing.T) {
+	line := NewLineString([]*Point{{0, 0}, {0, 1}, {1, 1}})
+
+	Convey("Given a point millimetres off a line, should find the segment and the offset along the line", t, func() {
+		segment, offset, ok := IsPointOnLineWithin(NewPoint(1e-8, 0.5), line, 0.01, Meters, false)
+		So(ok, ShouldBeTrue)
+		So(segment, ShouldEqual, 0)
+		So(offset, ShouldAlmostEqual, Distance(NewPoint(0, 0), NewPoint(0, 0.5), Meters), 0.000001)
+
+		segment, offset, ok = IsPointOnLineWithin(NewPoint(0.5, 1+1e-8), line, 0.01, Meters, false)
+		So(ok, ShouldBeTrue)
+		So(segment, ShouldEqual, 1)
+		So(offset, ShouldAlmostEqual, Distance(NewPoint(0, 0), NewPoint(0, 1), Meters)+Distance(NewPoint(0, 1), NewPoint(0.5, 1), Meters), 0.000001)
+	})
+
+	Convey("Given a point farther than the tolerance, should not be on the line", t, func() {
+		segment, offset, ok := IsPointOnLineWithin(NewPoint(1e-8, 0.5), line, 0.001, Meters, false)
+		So(ok, ShouldBeFalse)
+		So(segment, ShouldEqual, -1)
+		So(offset, ShouldEqual, 0)
+		_, _, ok = IsPointOnLineWithin(NewPoint(0, -0.001), line, 1, Meters, false)
+		So(ok, ShouldBeFalse)
+		_, _, ok = IsPointOnLineWithin(NewPoint(0, 0), NewLineString([]*Point{{0, 0}}), 1, Meters, false)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Given the end points of a line, should only be on it if ends are not ignored", t, func() {
+		segment, offset, ok := IsPointOnLineWithin(NewPoint(0, 0), line, 0.01, Meters, false)
+		So(ok, ShouldBeTrue)
+		So(segment, ShouldEqual, 0)
+		So(offset, ShouldEqual, 0)
+		segment, offset, ok = IsPointOnLineWithin(NewPoint(1, 1), line, 0.01, Meters, false)
+		So(ok, ShouldBeTrue)
+		So(segment, ShouldEqual, 1)
+		So(offset, ShouldAlmostEqual, Length(line, Meters), 0.000001)
+		_, _, ok = IsPointOnLineWithin(NewPoint(0, 0), line, 0.01, Meters, true)
+		So(ok, ShouldBeFalse)
+		_, _, ok = IsPointOnLineWithin(NewPoint(1, 1+1e-9), line, 0.01, Meters, true)
+		So(ok, ShouldBeFalse)
+		_, _, ok = IsPointOnLineWithin(NewPoint(0, 1), line, 0.01, Meters, true)
+		So(ok, ShouldBeTrue)
+	})
+
+	Convey("Given a long segment, should follow the great circle rather than the parallel", t, func() {
+		segment := NewLineString([]*Point{{60, 0}, {60, 10}})
+		vertexLat := RadsToDegree(math.Atan(math.Tan(DegreeToRads(60)) / math.Cos(DegreeToRads(5))))
+		_, offset, ok := IsPointOnLineWithin(NewPoint(vertexLat, 5), segment, 0.01, Meters, false)
+		So(ok, ShouldBeTrue)
+		So(offset, ShouldAlmostEqual, Distance(NewPoint(60, 0), NewPoint(60, 10), Meters)/2, 0.001)
+		_, _, ok = IsPointOnLineWithin(NewPoint(60, 5), segment, 1000, Meters, false)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Given WGS84 as earth model, should still measure the tolerance on the sphere", t, func() {
+		equator := NewLineString([]*Point{{0, 0}, {0, 10}})
+		point := NewPoint(0.5, 5)
+		// about 55.6 km on the sphere and 55.3 km on the ellipsoid
+		tolerance := haversineDistance(point, NewPoint(0, 5), Kilometers)
+		SetEarthModel(WGS84)
+		defer SetEarthModel(Sphere)
+		So(Distance(point, NewPoint(0, 5), Kilometers), ShouldBeLessThan, 0.999*tolerance)
+		_, _, ok := IsPointOnLineWithin(point, equator, 0.999*tolerance, Kilometers, false)
+		So(ok, ShouldBeFalse)
+		segment, offset, ok := IsPointOnLineWithin(point, equator, tolerance, Kilometers, false)
+		So(ok, ShouldBeTrue)
+		So(segment, ShouldEqual, 0)
+		So(offset, ShouldAlmostEqual, haversineDistance(NewPoint(0, 0), NewPoint(0, 5), Kilometers), 0.000001)
+	})
+
+	Convey("Given a ping on a route, should snap it to its segment", t, func() {
+		a, b := longRoute.Points[1000], longRoute.Points[1001]
+		ping := NewPoint((a.Lat+b.Lat)/2, (a.Lng+b.Lng)/2)
+		segment, offset, ok := IsPointOnLineWithin(ping, longRoute, 0.05, Meters, true)
+		So(ok, ShouldBeTrue)
+		So(segment, ShouldEqual, 1000)
+		So(offset, ShouldAlmostEqual, Length(NewLineString(longRoute.Points[:1001]), Meters)+Distance(a, b, Meters)/2, 0.01)
+	})
+}
+
+func BenchmarkIsPointOnLineWithin(b *testing.B) {
+	ping := longRoute.Points[2000]
+	for n := 0; n < b.N; n++ {
+		_, testResultF, testResultB = IsPointOnLineWithin(ping, longRoute, 5, Meters, false)
+	}
+}
+
+func TestIsClockwise(t *testing.T) {
+	Convey("Given rings, should tell if they are clockwise", t, func() {
+		So(IsClockwise(xyLine(0, 0, 1, 1, 1, 0, 0, 0)), ShouldBeTrue)
+		So(IsClockwise(xyLine(0, 0, 1, 0, 1, 1, 0, 0)), ShouldBeFalse)
+		So(IsClockwise(box(0, 0, 10, 10).LineStrings[0]), ShouldBeFalse)
+		So(IsClockwise(xyLine(0, 0, 0, 10, 10, 10, 10, 0)), ShouldBeTrue)
+		So(IsClockwise(NewLineString([]*Point{})), ShouldBeFalse)
+	})
+}
+
+func TestIsConvex(t *testing.T) {
+	Convey("Given polygons, should tell if they are convex or concave", t, func() {
+		convex := box(0, 0, 10, 10)
+		So(IsConvex(convex), ShouldBeTrue)
+		So(IsConcave(convex), ShouldBeFalse)
+
+		clockwise := NewPolygon([]*LineString{xyLine(0, 0, 0, 10, 5, 10, 10, 10, 10, 0, 0, 0)})
+		So(IsConvex(clockwise), ShouldBeTrue)
+
+		concave := NewPolygon([]*LineString{xyLine(0, 0, 10, 0, 10, 10, 5, 5, 0, 10, 0, 0)})
+		So(IsConvex(concave), ShouldBeFalse)
+		So(IsConcave(concave), ShouldBeTrue)
+
+		star := NewPolygon([]*LineString{xyLine(0, 0, 2, 6, 4, 0, -1, 4, 5, 4, 0, 0)})
+		So(IsConvex(star), ShouldBeFalse)
+		So(IsConcave(star), ShouldBeTrue)
+
+		withHole := box(0, 0, 10, 10, [4]float64{2, 2, 4, 4})
+		So(IsConvex(withHole), ShouldBeFalse)
+		So(IsConcave(withHole), ShouldBeFalse)
+
+		flat := NewPolygon([]*LineString{xyLine(0, 0, 1, 0, 0, 0)})
+		So(IsConvex(flat), ShouldBeFalse)
+		So(IsConcave(flat), ShouldBeFalse)
+		So(IsConcave(NewPolygon(nil)), ShouldBeFalse)
+	})
+}
@@ -1,5 +1,10 @@
 package turfgo
 
+import (
+	"container/heap"
+	"sort"
+)
+
 //Nearest takes a reference point and a set of points and returns the point from the set closest to the reference.
 func Nearest(reference *Point, points []*Point) *Point {
 	if len(points) == 0 {
@@ -16,3 +21,85 @@ func Nearest(reference *Point, points []*Point) *Point {
 	}
 	return nearestPoint
 }
+
+// Neighbour is a point found by a nearest neighbour search, with its distance to the reference and its index
+// in the searched points.
+type Neighbour struct {
+	Point    *Point
+	Distance float64
+	Index    int
+}
+
+// NearestK takes a reference point and a set of points and returns up to k points closest to the reference,
+// the closest first, within maxDistance in the given unit. All the points within maxDistance are returned when
+// k is not positive, pass math.Inf(1) as maxDistance for no limit. Points at the same distance are ordered by
+// index. Nil and empty points are skipped. NearestK is the linear baseline: it measures the distance to every
+// point and keeps the k closest, in O(n log k) for each query. A PointIndex answers the same queries by only
+// visiting the parts of its tree closer than the k-th neighbour, build one to search the same points many times.
+func NearestK(reference *Point, points []*Point, k int, maxDistance float64, unit Unit) []Neighbour {
+	neighbours := &neighbourHeap{}
+	for i, point := range points {
+		if point == nil || isEmptyPoint(point) {
+			continue
+		}
+		distance := Distance(reference, point, unit)
+		if !(distance <= maxDistance) {
+			continue
+		}
+		neighbour := Neighbour{point, distance, i}
+		if k <= 0 || neighbours.Len() < k {
+			heap.Push(neighbours, neighbour)
+		} else if closer(neighbour, (*neighbours)[0]) {
+			(*neighbours)[0] = neighbour
+			heap.Fix(neighbours, 0)
+		}
+	}
+	sorted := []Neighbour(*neighbours)
+	sort.Slice(sorted, func(i, j int) bool { return closer(sorted[i], sorted[j]) })
+	return sorted
+}
+
+func closer(a, b Neighbour) bool {
+	if a.Distance == b.Distance {
+		return a.Index < b.Index
+	}
+	return a.Distance < b.Distance
+}
+
+// neighbourHeap is a max heap of neighbours, the farthest one first
+type neighbourHeap []Neighbour
+
+func (h neighbourHeap) Len() int            { return len(h) }
+func (h neighbourHeap) Less(i, j int) bool  { return closer(h[j], h[i]) }
+func (h neighbourHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighbourHeap) Push(x interface{}) { *h = append(*h, x.(Neighbour)) }
+
+func (h *neighbourHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// PointIndex is a set of points indexed in an RTree for repeated nearest neighbour searches
+type PointIndex struct {
+	tree *RTree
+}
+
+// NewPointIndex bulk loads points in a PointIndex, nil and empty points are not indexed
+func NewPointIndex(points []*Point) *PointIndex {
+	return &PointIndex{newPointTree(points)}
+}
+
+// NearestK is like the NearestK function on the indexed points, the index of a neighbour is the index of its
+// point in the slice given to NewPointIndex. It only visits the parts of the index closer than the k-th
+// neighbour.
+func (index *PointIndex) NearestK(reference *Point, k int, maxDistance float64, unit Unit) []Neighbour {
+	neighbours := []Neighbour{}
+	index.tree.nearest(reference, maxDistance, unit, func(geometry Geometry, distance float64) bool {
+		point := geometry.(*indexedPoint)
+		neighbours = append(neighbours, Neighbour{point.Point, distance, point.index})
+		return k <= 0 || len(neighbours) < k
+	})
+	return neighbours
+}
@@ -1,6 +1,7 @@
 package turfgo
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -42,3 +43,64 @@ func TestNearest(t *testing.T) {
 	})
 
 }
+
+func TestNearestK(t *testing.T) {
+	ref := NewPoint(0, 0)
+	p1, p2, p3, p4 := NewPoint(0, 1), NewPoint(0, -1), NewPoint(2, 0), NewPoint(0, 0.5)
+	points := []*Point{p1, p2, p3, p4, NewPoint(math.NaN(), math.NaN())}
+	d1, d3, d4 := Distance(ref, p1, Kilometers), Distance(ref, p3, Kilometers), Distance(ref, p4, Kilometers)
+
+	Convey("Given a reference point and points, should return the k nearest with distances and indices", t, func() {
+		So(NearestK(ref, points, 3, math.Inf(1), Kilometers), ShouldResemble,
+			[]Neighbour{{p4, d4, 3}, {p1, d1, 0}, {p2, d1, 1}})
+		So(NearestK(ref, points, 0, math.Inf(1), Kilometers), ShouldResemble,
+			[]Neighbour{{p4, d4, 3}, {p1, d1, 0}, {p2, d1, 1}, {p3, d3, 2}})
+	})
+
+	Convey("Given a maximum distance, should only return the points within it", t, func() {
+		So(NearestK(ref, points, 3, 100, Kilometers), ShouldResemble, []Neighbour{{p4, d4, 3}})
+		So(NearestK(ref, points, 3, 1, Kilometers), ShouldBeEmpty)
+		So(NearestK(ref, []*Point{}, 3, math.Inf(1), Kilometers), ShouldBeEmpty)
+	})
+
+	Convey("Given nil and empty points, should skip them and keep the indices of the others", t, func() {
+		withNil := []*Point{nil, p1, NewPoint(math.NaN(), math.NaN()), nil, p4}
+		So(NearestK(ref, withNil, 0, math.Inf(1), Kilometers), ShouldResemble, []Neighbour{{p4, d4, 4}, {p1, d1, 1}})
+		So(NewPointIndex(withNil).NearestK(ref, 0, math.Inf(1), Kilometers), ShouldResemble,
+			NearestK(ref, withNil, 0, math.Inf(1), Kilometers))
+		So(NearestK(ref, []*Point{nil}, 3, math.Inf(1), Kilometers), ShouldBeEmpty)
+	})
+
+	Convey("Given a point index, should return the same neighbours as NearestK", t, func() {
+		index := NewPointIndex(points)
+		So(index.NearestK(ref, 1, math.Inf(1), Kilometers), ShouldResemble, []Neighbour{{p4, d4, 3}})
+		So(index.NearestK(ref, 0, 200, Miles), ShouldResemble, NearestK(ref, points, 0, 200, Miles))
+		So(index.NearestK(ref, 3, 1, Kilometers), ShouldBeEmpty)
+
+		random := randomPoints(3000, 3)
+		index = NewPointIndex(random)
+		for _, reference := range []*Point{NewPoint(10, 10), NewPoint(-70, -179), NewPoint(89, 0)} {
+			So(index.NearestK(reference, 15, math.Inf(1), Meters), ShouldResemble,
+				NearestK(reference, random, 15, math.Inf(1), Meters))
+			So(index.NearestK(reference, 0, 500, Kilometers), ShouldResemble,
+				NearestK(reference, random, 0, 500, Kilometers))
+		}
+	})
+}
+
+func BenchmarkNearestK(b *testing.B) {
+	points := randomPoints(10000, 1)
+	reference := NewPoint(12, 34)
+	for n := 0; n < b.N; n++ {
+		NearestK(reference, points, 10, math.Inf(1), Kilometers)
+	}
+}
+
+func BenchmarkPointIndexNearestK(b *testing.B) {
+	index := NewPointIndex(randomPoints(10000, 1))
+	reference := NewPoint(12, 34)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		index.NearestK(reference, 10, math.Inf(1), Kilometers)
+	}
+}
@@ -0,0 +1,322 @@
+package turfgo
+
+import (
+	"math"
+	"sort"
+)
+
+// delaunay is the Delaunay triangulation of a set of points, computed with the sweep-hull algorithm of
+// delaunator (https://github.com/mapbox/delaunator). Every three values of triangles are the indexes of the
+// points of a clockwise triangle. Half edge e goes from triangles[e] to triangles[nextHalfEdge(e)],
+// halfEdges[e] is the opposite half edge in the adjacent triangle, or -1 on the hull. hull holds the indexes of
+// the points of the convex hull, clockwise. Duplicated points are left out of the triangulation, collinear
+// points give no triangle and a hull ordered along their line.
+type delaunay struct {
+	points    []vec
+	triangles []int
+	halfEdges []int
+	hull      []int
+
+	// state of the sweep
+	center                    vec
+	hashSize                  int
+	hullStart                 int
+	hullPrev, hullNext, hullT []int
+	hullHash                  []int
+}
+
+func newDelaunay(points []vec) *delaunay {
+	n := len(points)
+	d := &delaunay{points: points}
+	if n < 3 {
+		d.collinearHull()
+		return d
+	}
+
+	bounds := ringRect(points)
+	middle := vec{(bounds.minX + bounds.maxX) / 2, (bounds.minY + bounds.maxY) / 2}
+	// seed triangle: the point closest to the middle, the point closest to it and the point giving the
+	// smallest circumcircle with them
+	i0, i1, i2 := closestPoint(points, middle, -1), -1, -1
+	minDist := math.Inf(1)
+	for i, p := range points {
+		if dist := sqDist(points[i0], p); i != i0 && dist < minDist && dist > 0 {
+			i1, minDist = i, dist
+		}
+	}
+	minRadius := math.Inf(1)
+	for i, p := range points {
+		if i1 == -1 || i == i0 || i == i1 {
+			continue
+		}
+		if r := circumradius(points[i0], points[i1], p); r < minRadius {
+			i2, minRadius = i, r
+		}
+	}
+	if math.IsInf(minRadius, 1) || math.IsNaN(minRadius) {
+		d.collinearHull()
+		return d
+	}
+	if orient(points[i0], points[i1], points[i2]) {
+		i1, i2 = i2, i1
+	}
+	d.center = circumcenter(points[i0], points[i1], points[i2])
+
+	ids := make([]int, n)
+	dists := make([]float64, n)
+	for i, p := range points {
+		ids[i], dists[i] = i, sqDist(p, d.center)
+	}
+	sort.Slice(ids, func(a, b int) bool { return dists[ids[a]] < dists[ids[b]] })
+
+	maxTriangles := 2*n - 5
+	d.triangles = make([]int, 0, maxTriangles*3)
+	d.halfEdges = make([]int, 0, maxTriangles*3)
+	d.hashSize = int(math.Ceil(math.Sqrt(float64(n))))
+	d.hullPrev, d.hullNext, d.hullT = make([]int, n), make([]int, n), make([]int, n)
+	d.hullHash = make([]int, d.hashSize)
+	for i := range d.hullHash {
+		d.hullHash[i] = -1
+	}
+
+	d.hullStart = i0
+	hullSize := 3
+	d.hullNext[i0], d.hullPrev[i2] = i1, i1
+	d.hullNext[i1], d.hullPrev[i0] = i2, i2
+	d.hullNext[i2], d.hullPrev[i1] = i0, i0
+	d.hullT[i0], d.hullT[i1], d.hullT[i2] = 0, 1, 2
+	d.hullHash[d.hashKey(points[i0])] = i0
+	d.hullHash[d.hashKey(points[i1])] = i1
+	d.hullHash[d.hashKey(points[i2])] = i2
+	d.addTriangle(i0, i1, i2, -1, -1, -1)
+
+	var previous vec
+	for k, i := range ids {
+		p := points[i]
+		// skip duplicated points and the seed triangle
+		if k > 0 && math.Abs(p.x-previous.x) <= delaunayEpsilon && math.Abs(p.y-previous.y) <= delaunayEpsilon {
+			continue
+		}
+		previous = p
+		if i == i0 || i == i1 || i == i2 {
+			continue
+		}
+
+		// find an edge of the hull visible from the point
+		start := 0
+		for j, key := 0, d.hashKey(p); j < d.hashSize; j++ {
+			start = d.hullHash[(key+j)%d.hashSize]
+			if start != -1 && start != d.hullNext[start] {
+				break
+			}
+		}
+		start = d.hullPrev[start]
+		e := start
+		for !orient(p, points[e], points[d.hullNext[e]]) {
+			e = d.hullNext[e]
+			if e == start {
+				e = -1
+				break
+			}
+		}
+		if e == -1 {
+			// a point very close to an existing one
+			continue
+		}
+
+		// add the first triangle from the point and make the triangles Delaunay by flipping edges
+		t := d.addTriangle(e, i, d.hullNext[e], -1, -1, d.hullT[e])
+		d.hullT[i] = d.legalize(t + 2)
+		d.hullT[e] = t
+		hullSize++
+
+		// walk forward along the hull, adding triangles
+		next := d.hullNext[e]
+		for q := d.hullNext[next]; orient(p, points[next], points[q]); q = d.hullNext[next] {
+			t = d.addTriangle(next, i, q, d.hullT[i], -1, d.hullT[next])
+			d.hullT[i] = d.legalize(t + 2)
+			d.hullNext[next] = next // removed from the hull
+			hullSize--
+			next = q
+		}
+		// walk backward from the other side
+		if e == start {
+			for q := d.hullPrev[e]; orient(p, points[q], points[e]); q = d.hullPrev[e] {
+				t = d.addTriangle(q, i, e, -1, d.hullT[e], d.hullT[q])
+				d.legalize(t + 2)
+				d.hullT[q] = t
+				d.hullNext[e] = e // removed from the hull
+				hullSize--
+				e = q
+			}
+		}
+
+		d.hullStart = e
+		d.hullPrev[i], d.hullNext[e] = e, i
+		d.hullPrev[next], d.hullNext[i] = i, next
+		d.hullHash[d.hashKey(p)] = i
+		d.hullHash[d.hashKey(points[e])] = e
+	}
+
+	d.hull = make([]int, hullSize)
+	for i, e := 0, d.hullStart; i < hullSize; i++ {
+		d.hull[i] = e
+		e = d.hullNext[e]
+	}
+	d.hullPrev, d.hullNext, d.hullT, d.hullHash = nil, nil, nil, nil
+	return d
+}
+
+const delaunayEpsilon = 1.0 / (1 << 52)
+
+// collinearHull orders points with no triangle along their line
+func (d *delaunay) collinearHull() {
+	if len(d.points) == 0 {
+		return
+	}
+	origin := d.points[0]
+	dists := make([]float64, len(d.points))
+	ids := make([]int, len(d.points))
+	for i, p := range d.points {
+		ids[i] = i
+		dists[i] = p.x - origin.x
+		if dists[i] == 0 {
+			dists[i] = p.y - origin.y
+		}
+	}
+	sort.Slice(ids, func(a, b int) bool { return dists[ids[a]] < dists[ids[b]] })
+	last := math.Inf(-1)
+	for _, id := range ids {
+		if dists[id] > last {
+			d.hull = append(d.hull, id)
+			last = dists[id]
+		}
+	}
+}
+
+func (d *delaunay) hashKey(p vec) int {
+	return int(math.Floor(pseudoAngle(p.x-d.center.x, p.y-d.center.y)*float64(d.hashSize))) % d.hashSize
+}
+
+// pseudoAngle increases monotonically with the angle of a vector, from 0 to 1
+func pseudoAngle(dx, dy float64) float64 {
+	p := dx / (math.Abs(dx) + math.Abs(dy))
+	if dy > 0 {
+		return (3 - p) / 4
+	}
+	return (1 + p) / 4
+}
+
+func (d *delaunay) addTriangle(i0, i1, i2, a, b, c int) int {
+	t := len(d.triangles)
+	d.triangles = append(d.triangles, i0, i1, i2)
+	d.halfEdges = append(d.halfEdges, -1, -1, -1)
+	d.link(t, a)
+	d.link(t+1, b)
+	d.link(t+2, c)
+	return t
+}
+
+func (d *delaunay) link(a, b int) {
+	d.halfEdges[a] = b
+	if b != -1 {
+		d.halfEdges[b] = a
+	}
+}
+
+// legalize flips the edges around half edge a until the triangles satisfy the Delaunay condition
+func (d *delaunay) legalize(a int) int {
+	stack := []int{}
+	ar := 0
+	for {
+		b := d.halfEdges[a]
+		a0 := a - a%3
+		ar = a0 + (a+2)%3
+		if b == -1 {
+			if len(stack) == 0 {
+				break
+			}
+			a, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			continue
+		}
+		b0 := b - b%3
+		al := a0 + (a+1)%3
+		bl := b0 + (b+2)%3
+		p0, pr, pl, p1 := d.triangles[ar], d.triangles[a], d.triangles[al], d.triangles[bl]
+		if !inCircle(d.points[p0], d.points[pr], d.points[pl], d.points[p1]) {
+			if len(stack) == 0 {
+				break
+			}
+			a, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			continue
+		}
+		d.triangles[a] = p1
+		d.triangles[b] = p0
+		hbl := d.halfEdges[bl]
+		if hbl == -1 {
+			// the edge swapped was on the hull, on the other side of it
+			e := d.hullStart
+			for {
+				if d.hullT[e] == bl {
+					d.hullT[e] = a
+					break
+				}
+				e = d.hullPrev[e]
+				if e == d.hullStart {
+					break
+				}
+			}
+		}
+		d.link(a, hbl)
+		d.link(b, d.halfEdges[ar])
+		d.link(ar, bl)
+		stack = append(stack, b0+(b+1)%3)
+	}
+	return ar
+}
+
+func nextHalfEdge(e int) int {
+	if e%3 == 2 {
+		return e - 2
+	}
+	return e + 1
+}
+
+func closestPoint(points []vec, p vec, skip int) int {
+	closest, minDist := -1, math.Inf(1)
+	for i, q := range points {
+		if dist := sqDist(p, q); i != skip && dist < minDist {
+			closest, minDist = i, dist
+		}
+	}
+	return closest
+}
+
+func sqDist(a, b vec) float64 {
+	d := a.sub(b)
+	return dot(d, d)
+}
+
+// orient tells if p, q and r are counter-clockwise
+func orient(p, q, r vec) bool {
+	return orient2d(p, q, r) > 0
+}
+
+// inCircle tells if p is inside the circumcircle of the clockwise triangle abc
+func inCircle(a, b, c, p vec) bool {
+	return inCircle2d(a, b, c, p) < 0
+}
+
+// circumradius returns the square of the radius of the circle through a, b and c
+func circumradius(a, b, c vec) float64 {
+	return sqDist(circumcenter(a, b, c), a)
+}
+
+func circumcenter(a, b, c vec) vec {
+	dx, dy := b.x-a.x, b.y-a.y
+	ex, ey := c.x-a.x, c.y-a.y
+	bl := dx*dx + dy*dy
+	cl := ex*ex + ey*ey
+	d := 0.5 / (dx*ey - dy*ex)
+	return vec{a.x + (ey*bl-dy*cl)*d, a.y + (dx*cl-ex*bl)*d}
+}
@@ -0,0 +1,87 @@
+package turfgo
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDelaunay(t *testing.T) {
+	Convey("Given random points, should triangulate them with empty circumcircles", t, func() {
+		r := rand.New(rand.NewSource(42))
+		points := make([]vec, 200)
+		for i := range points {
+			points[i] = vec{r.Float64(), r.Float64()}
+		}
+		d := newDelaunay(points)
+		// a triangulation of n points with h on the hull has 2n-2-h triangles
+		So(len(d.triangles)/3, ShouldEqual, 2*len(points)-2-len(d.hull))
+		for t := 0; t < len(d.triangles); t += 3 {
+			a, b, c := points[d.triangles[t]], points[d.triangles[t+1]], points[d.triangles[t+2]]
+			So(orient(a, b, c), ShouldBeFalse)
+			center, radius := circumcenter(a, b, c), circumradius(a, b, c)
+			for _, p := range points {
+				So(sqDist(p, center), ShouldBeGreaterThan, 0.9999*radius)
+			}
+		}
+		for e, opposite := range d.halfEdges {
+			if opposite != -1 {
+				So(d.halfEdges[opposite], ShouldEqual, e)
+				So(d.triangles[opposite], ShouldEqual, d.triangles[nextHalfEdge(e)])
+			}
+		}
+	})
+
+	Convey("Given a grid with duplicated points, should triangulate the distinct points", t, func() {
+		points := []vec{}
+		for x := 0; x < 10; x++ {
+			for y := 0; y < 10; y++ {
+				points = append(points, vec{float64(x), float64(y)}, vec{float64(x), float64(y)})
+			}
+		}
+		d := newDelaunay(points)
+		So(len(d.triangles)/3, ShouldEqual, 2*81)
+	})
+
+	Convey("Given a fine grid of coordinates, should triangulate it with exactly empty circumcircles", t, func() {
+		r := rand.New(rand.NewSource(1))
+		for _, jitter := range []float64{0, 2e-16} {
+			points := []vec{}
+			for x := 0; x < 20; x++ {
+				for y := 0; y < 20; y++ {
+					// rounding of the grid steps makes the cells almost, but not exactly, cocircular
+					points = append(points, vec{-77.03 + float64(x)*1e-6 + (r.Float64()-0.5)*jitter*77,
+						38.89 + float64(y)*1e-6 + (r.Float64()-0.5)*jitter*39})
+				}
+			}
+			d := newDelaunay(points)
+			So(len(d.triangles)/3, ShouldEqual, 2*len(points)-2-len(d.hull))
+			for t := 0; t < len(d.triangles); t += 3 {
+				a, b, c := points[d.triangles[t]], points[d.triangles[t+1]], points[d.triangles[t+2]]
+				So(orient2d(a, b, c), ShouldBeLessThan, 0)
+				for _, p := range points {
+					So(inCircle2d(a, b, c, p), ShouldBeGreaterThanOrEqualTo, 0)
+				}
+			}
+		}
+	})
+
+	Convey("Given collinear points, should return no triangle and order the hull along the line", t, func() {
+		d := newDelaunay([]vec{{2, 2}, {0, 0}, {3, 3}, {1, 1}})
+		So(d.triangles, ShouldBeEmpty)
+		So(d.hull, ShouldResemble, []int{1, 3, 0, 2})
+	})
+}
+
+func BenchmarkDelaunay(b *testing.B) {
+	b.StopTimer()
+	points := make([]vec, len(longRoute.Points))
+	for i, point := range longRoute.Points {
+		points[i] = pointToVec(point)
+	}
+	b.StartTimer()
+	for n := 0; n < b.N; n++ {
+		newDelaunay(points)
+	}
+}
@@ -2,11 +2,36 @@ package turfgo
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/kpawlik/geojson"
-	"errors"
 )
 
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type geoJSONGeometryCollectionObject struct {
+	Type       string            `json:"type"`
+	Geometries []json.RawMessage `json:"geometries"`
+}
+
+type geoJSONFeatureObject struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	BBox       []float64              `json:"bbox,omitempty"`
+	Geometry   json.RawMessage        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONFeatureCollectionObject struct {
+	Type     string     `json:"type"`
+	BBox     []float64  `json:"bbox,omitempty"`
+	Features []*Feature `json:"features"`
+}
+
 func DecodeFeatureCollection(gj []byte) (*geojson.FeatureCollection, error) {
 	var f *geojson.FeatureCollection
 	err := json.Unmarshal(gj, &f)
@@ -34,6 +59,413 @@ func DecodeLineStringFromFeatureJSON(gj []byte) (*LineString, error) {
 	return decodeLine(ls), nil
 }
 
+// EncodeGeometry encodes a Point, MultiPoint, LineString, MultiLineString, Polygon, MultiPolygon
+// or GeometryCollection into a geojson geometry object. Positions are written in [lng, lat] order
+// as specified by RFC 7946, an empty point is written with empty coordinates. Features and feature
+// collections are encoded as geojson Feature and FeatureCollection objects.
+func EncodeGeometry(geometry Geometry) ([]byte, error) {
+	var geometryType string
+	var coordinates interface{}
+	switch g := geometry.(type) {
+	case *Feature, *FeatureCollection:
+		return json.Marshal(g)
+	case *Point:
+		geometryType, coordinates = geoJSONPoint, encodePosition(g)
+	case *MultiPoint:
+		geometryType, coordinates = geoJSONMultiPoint, encodePositions(g.Points)
+	case *LineString:
+		geometryType, coordinates = geoJSONLineString, encodePositions(g.Points)
+	case *MultiLineString:
+		geometryType, coordinates = geoJSONMultiLineString, encodeLineStrings(g.LineStrings)
+	case *Polygon:
+		geometryType, coordinates = geoJSONPolygon, encodeLineStrings(g.LineStrings)
+	case *MultiPolygon:
+		polygons := [][][][]float64{}
+		for _, polygon := range g.Polygons {
+			polygons = append(polygons, encodeLineStrings(polygon.LineStrings))
+		}
+		geometryType, coordinates = geoJSONMultiPolygon, polygons
+	case *GeometryCollection:
+		return encodeGeometryCollection(g)
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %T", geometry)
+	}
+	c, err := json.Marshal(coordinates)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&geoJSONGeometry{Type: geometryType, Coordinates: c})
+}
+
+// DecodeGeometry decodes a geojson geometry object into a *Point, *MultiPoint, *LineString,
+// *MultiLineString, *Polygon, *MultiPolygon or *GeometryCollection. Positions are read in [lng, lat]
+// order. geojson Feature and FeatureCollection objects are decoded into *Feature and *FeatureCollection.
+func DecodeGeometry(gj []byte) (Geometry, error) {
+	var g *geoJSONGeometry
+	err := json.Unmarshal(gj, &g)
+	if err != nil {
+		return nil, err
+	}
+	if g == nil {
+		return nil, errors.New("geometry can't be null")
+	}
+	switch g.Type {
+	case geoJSONFeature:
+		f := &Feature{}
+		if err := json.Unmarshal(gj, f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case geoJSONFeatureCollection:
+		fc := &FeatureCollection{}
+		if err := json.Unmarshal(gj, fc); err != nil {
+			return nil, err
+		}
+		return fc, nil
+	case geoJSONPoint:
+		var c []float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		if len(c) == 0 {
+			return emptyPoint(), nil
+		}
+		return decodePosition(c)
+	case geoJSONMultiPoint:
+		var c [][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		points, err := decodePositions(c)
+		if err != nil {
+			return nil, err
+		}
+		return NewMultiPoint(points), nil
+	case geoJSONLineString:
+		var c [][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		points, err := decodePositions(c)
+		if err != nil {
+			return nil, err
+		}
+		return NewLineString(points), nil
+	case geoJSONMultiLineString:
+		var c [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		lineStrings, err := decodeLineStrings(c)
+		if err != nil {
+			return nil, err
+		}
+		return NewMultiLineString(lineStrings), nil
+	case geoJSONPolygon:
+		var c [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		lineStrings, err := decodeLineStrings(c)
+		if err != nil {
+			return nil, err
+		}
+		return NewPolygon(lineStrings), nil
+	case geoJSONMultiPolygon:
+		var c [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		polygons := []*Polygon{}
+		for _, rings := range c {
+			lineStrings, err := decodeLineStrings(rings)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, NewPolygon(lineStrings))
+		}
+		return NewMultiPolygon(polygons), nil
+	case geoJSONGeometryCollection:
+		return decodeGeometryCollection(gj)
+	}
+	return nil, fmt.Errorf("unknown geometry type %s", g.Type)
+}
+
+// EncodePoint encodes a point into a geojson Point geometry.
+func EncodePoint(point *Point) ([]byte, error) {
+	return EncodeGeometry(point)
+}
+
+// DecodePoint decodes a geojson Point geometry into *Point
+func DecodePoint(gj []byte) (*Point, error) {
+	g, err := DecodeGeometry(gj)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := g.(*Point)
+	if !ok {
+		return nil, errors.New("geometry is not of type point")
+	}
+	return p, nil
+}
+
+// EncodeMultiPoint encodes a multiPoint into a geojson MultiPoint geometry.
+func EncodeMultiPoint(multiPoint *MultiPoint) ([]byte, error) {
+	return EncodeGeometry(multiPoint)
+}
+
+// DecodeMultiPoint decodes a geojson MultiPoint geometry into *MultiPoint
+func DecodeMultiPoint(gj []byte) (*MultiPoint, error) {
+	g, err := DecodeGeometry(gj)
+	if err != nil {
+		return nil, err
+	}
+	mp, ok := g.(*MultiPoint)
+	if !ok {
+		return nil, errors.New("geometry is not of type multipoint")
+	}
+	return mp, nil
+}
+
+// EncodeLineString encodes a lineString into a geojson LineString geometry.
+func EncodeLineString(lineString *LineString) ([]byte, error) {
+	return EncodeGeometry(lineString)
+}
+
+// DecodeLineString decodes a geojson LineString geometry into *LineString
+func DecodeLineString(gj []byte) (*LineString, error) {
+	g, err := DecodeGeometry(gj)
+	if err != nil {
+		return nil, err
+	}
+	ls, ok := g.(*LineString)
+	if !ok {
+		return nil, errors.New("geometry is not of type linestring")
+	}
+	return ls, nil
+}
+
+// EncodeMultiLineString encodes a multiLineString into a geojson MultiLineString geometry.
+func EncodeMultiLineString(multiLineString *MultiLineString) ([]byte, error) {
+	return EncodeGeometry(multiLineString)
+}
+
+// DecodeMultiLineString decodes a geojson MultiLineString geometry into *MultiLineString
+func DecodeMultiLineString(gj []byte) (*MultiLineString, error) {
+	g, err := DecodeGeometry(gj)
+	if err != nil {
+		return nil, err
+	}
+	mls, ok := g.(*MultiLineString)
+	if !ok {
+		return nil, errors.New("geometry is not of type multilinestring")
+	}
+	return mls, nil
+}
+
+// EncodePolygon encodes a polygon into a geojson Polygon geometry.
+func EncodePolygon(polygon *Polygon) ([]byte, error) {
+	return EncodeGeometry(polygon)
+}
+
+// DecodePolygon decodes a geojson Polygon geometry into *Polygon
+func DecodePolygon(gj []byte) (*Polygon, error) {
+	g, err := DecodeGeometry(gj)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := g.(*Polygon)
+	if !ok {
+		return nil, errors.New("geometry is not of type polygon")
+	}
+	return p, nil
+}
+
+// EncodeMultiPolygon encodes a multiPolygon into a geojson MultiPolygon geometry.
+func EncodeMultiPolygon(multiPolygon *MultiPolygon) ([]byte, error) {
+	return EncodeGeometry(multiPolygon)
+}
+
+// DecodeMultiPolygon decodes a geojson MultiPolygon geometry into *MultiPolygon
+func DecodeMultiPolygon(gj []byte) (*MultiPolygon, error) {
+	g, err := DecodeGeometry(gj)
+	if err != nil {
+		return nil, err
+	}
+	mp, ok := g.(*MultiPolygon)
+	if !ok {
+		return nil, errors.New("geometry is not of type multipolygon")
+	}
+	return mp, nil
+}
+
+// EncodeGeometryCollection encodes a geometryCollection into a geojson GeometryCollection geometry.
+func EncodeGeometryCollection(geometryCollection *GeometryCollection) ([]byte, error) {
+	return EncodeGeometry(geometryCollection)
+}
+
+// DecodeGeometryCollection decodes a geojson GeometryCollection geometry into *GeometryCollection
+func DecodeGeometryCollection(gj []byte) (*GeometryCollection, error) {
+	g, err := DecodeGeometry(gj)
+	if err != nil {
+		return nil, err
+	}
+	gc, ok := g.(*GeometryCollection)
+	if !ok {
+		return nil, errors.New("geometry is not of type geometrycollection")
+	}
+	return gc, nil
+}
+
+func encodeGeometryCollection(gc *GeometryCollection) ([]byte, error) {
+	geometries := []json.RawMessage{}
+	for _, geometry := range gc.Geometries {
+		if !isGeoJSONGeometry(geometry) {
+			return nil, errors.New("geometryCollection can't hold a feature or featureCollection")
+		}
+		g, err := EncodeGeometry(geometry)
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, g)
+	}
+	return json.Marshal(&geoJSONGeometryCollectionObject{Type: geoJSONGeometryCollection, Geometries: geometries})
+}
+
+func decodeGeometryCollection(gj []byte) (*GeometryCollection, error) {
+	var gc geoJSONGeometryCollectionObject
+	err := json.Unmarshal(gj, &gc)
+	if err != nil {
+		return nil, err
+	}
+	geometries := []Geometry{}
+	for _, g := range gc.Geometries {
+		geometry, err := DecodeGeometry(g)
+		if err != nil {
+			return nil, err
+		}
+		if !isGeoJSONGeometry(geometry) {
+			return nil, errors.New("geometryCollection can't hold a feature or featureCollection")
+		}
+		geometries = append(geometries, geometry)
+	}
+	return NewGeometryCollection(geometries), nil
+}
+
+// MarshalJSON encodes the feature into a geojson Feature object
+func (f *Feature) MarshalJSON() ([]byte, error) {
+	var geometry json.RawMessage
+	if f.Geometry != nil {
+		if !isGeoJSONGeometry(f.Geometry) {
+			return nil, errors.New("feature geometry can't be a feature or featureCollection")
+		}
+		g, err := EncodeGeometry(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		geometry = g
+	}
+	return json.Marshal(&geoJSONFeatureObject{
+		Type:       geoJSONFeature,
+		ID:         f.ID,
+		BBox:       encodeBBox(f.BoundingBox),
+		Geometry:   geometry,
+		Properties: f.Properties,
+	})
+}
+
+// UnmarshalJSON decodes a geojson Feature object into the feature
+func (f *Feature) UnmarshalJSON(gj []byte) error {
+	var gf geoJSONFeatureObject
+	err := json.Unmarshal(gj, &gf)
+	if err != nil {
+		return err
+	}
+	if gf.Type != geoJSONFeature {
+		return errors.New("object is not of type feature")
+	}
+	var geometry Geometry
+	if len(gf.Geometry) > 0 && string(gf.Geometry) != "null" {
+		geometry, err = DecodeGeometry(gf.Geometry)
+		if err != nil {
+			return err
+		}
+		if !isGeoJSONGeometry(geometry) {
+			return errors.New("feature geometry can't be a feature or featureCollection")
+		}
+	}
+	bbox, err := decodeBBox(gf.BBox)
+	if err != nil {
+		return err
+	}
+	*f = Feature{ID: gf.ID, Geometry: geometry, Properties: gf.Properties, BoundingBox: bbox}
+	return nil
+}
+
+// MarshalJSON encodes the featureCollection into a geojson FeatureCollection object
+func (fc *FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := fc.Features
+	if features == nil {
+		features = []*Feature{}
+	}
+	return json.Marshal(&geoJSONFeatureCollectionObject{
+		Type:     geoJSONFeatureCollection,
+		BBox:     encodeBBox(fc.BoundingBox),
+		Features: features,
+	})
+}
+
+// UnmarshalJSON decodes a geojson FeatureCollection object into the featureCollection
+func (fc *FeatureCollection) UnmarshalJSON(gj []byte) error {
+	var gfc geoJSONFeatureCollectionObject
+	err := json.Unmarshal(gj, &gfc)
+	if err != nil {
+		return err
+	}
+	if gfc.Type != geoJSONFeatureCollection {
+		return errors.New("object is not of type featureCollection")
+	}
+	bbox, err := decodeBBox(gfc.BBox)
+	if err != nil {
+		return err
+	}
+	for _, feature := range gfc.Features {
+		if feature == nil {
+			return errors.New("feature can't be null")
+		}
+	}
+	*fc = FeatureCollection{Features: gfc.Features, BoundingBox: bbox}
+	return nil
+}
+
+func isGeoJSONGeometry(geometry Geometry) bool {
+	switch geometry.(type) {
+	case *Feature, *FeatureCollection:
+		return false
+	}
+	return true
+}
+
+func encodeBBox(bbox *BoundingBox) []float64 {
+	if bbox == nil {
+		return nil
+	}
+	return []float64{bbox.West, bbox.South, bbox.East, bbox.North}
+}
+
+// decodeBBox accepts both 2D and 3D bboxes, altitude is ignored.
+func decodeBBox(bbox []float64) (*BoundingBox, error) {
+	switch len(bbox) {
+	case 0:
+		return nil, nil
+	case 4:
+		return NewBBox(bbox[0], bbox[1], bbox[2], bbox[3]), nil
+	case 6:
+		return NewBBox(bbox[0], bbox[1], bbox[3], bbox[4]), nil
+	}
+	return nil, errors.New("bbox should have four or six elements")
+}
+
 func decodeLine(ls *geojson.LineString) *LineString {
 	points := []*Point{}
 	for _, c := range ls.Coordinates {
@@ -45,3 +477,58 @@ func decodeLine(ls *geojson.LineString) *LineString {
 func decodePoint(coord geojson.Coordinate) *Point {
 	return &Point{float64(coord[1]), float64(coord[0])}
 }
+
+func encodePosition(point *Point) []float64 {
+	if isEmptyPoint(point) {
+		return []float64{}
+	}
+	return []float64{point.Lng, point.Lat}
+}
+
+func encodePositions(points []*Point) [][]float64 {
+	positions := [][]float64{}
+	for _, point := range points {
+		positions = append(positions, encodePosition(point))
+	}
+	return positions
+}
+
+func encodeLineStrings(lineStrings []*LineString) [][][]float64 {
+	lines := [][][]float64{}
+	for _, lineString := range lineStrings {
+		lines = append(lines, encodePositions(lineString.Points))
+	}
+	return lines
+}
+
+// decodePosition ignores any altitude or extra elements after lng and lat.
+func decodePosition(position []float64) (*Point, error) {
+	if len(position) < 2 {
+		return nil, errors.New("position should have at least two elements")
+	}
+	return &Point{position[1], position[0]}, nil
+}
+
+func decodePositions(positions [][]float64) ([]*Point, error) {
+	points := []*Point{}
+	for _, position := range positions {
+		point, err := decodePosition(position)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func decodeLineStrings(lines [][][]float64) ([]*LineString, error) {
+	lineStrings := []*LineString{}
+	for _, line := range lines {
+		points, err := decodePositions(line)
+		if err != nil {
+			return nil, err
+		}
+		lineStrings = append(lineStrings, NewLineString(points))
+	}
+	return lineStrings, nil
+}
@@ -1,6 +1,7 @@
 package turfgo
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"testing"
 
@@ -74,3 +75,224 @@ func TestDecodeFeatureCollection(t *testing.T) {
 		So(err.Error(), ShouldEqual, "invalid character 'i' looking for beginning of value")
 	})
 }
+
+func TestDecodeGeometry(t *testing.T) {
+	Convey("Given geoJson point, should return point", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/point.geojson")
+		p, err := DecodePoint(j)
+		So(err, ShouldBeNil)
+		So(p, ShouldResemble, &Point{22.466878364528448, -97.88131713867188})
+	})
+
+	Convey("Given geoJson multiPoint, should return multiPoint", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/multiPoint.geojson")
+		mp, err := DecodeMultiPoint(j)
+		So(err, ShouldBeNil)
+		So(mp.Points, ShouldResemble, []*Point{
+			{22.466878364528448, -97.88131713867188},
+			{22.175960091218524, -97.82089233398438},
+		})
+	})
+
+	Convey("Given geoJson lineString, should return lineString", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/lineString.geojson")
+		ls, err := DecodeLineString(j)
+		So(err, ShouldBeNil)
+		So(len(ls.Points), ShouldEqual, 3)
+		So(ls.Points[2], ShouldResemble, &Point{21.8704201873689, -97.6190185546875})
+	})
+
+	Convey("Given geoJson multiLineString, should return multiLineString", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/multiLineString.geojson")
+		mls, err := DecodeMultiLineString(j)
+		So(err, ShouldBeNil)
+		So(len(mls.LineStrings), ShouldEqual, 2)
+		So(mls.LineStrings[1].Points[1], ShouldResemble, &Point{21.7, -97.5})
+	})
+
+	Convey("Given geoJson polygon, should return polygon with holes", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/polygon.geojson")
+		p, err := DecodePolygon(j)
+		So(err, ShouldBeNil)
+		So(len(p.LineStrings), ShouldEqual, 2)
+		So(p.LineStrings[0].Points[1], ShouldResemble, &Point{0, 101})
+		So(p.LineStrings[1].Points[0], ShouldResemble, &Point{0.8, 100.8})
+	})
+
+	Convey("Given geoJson multiPolygon, should return multiPolygon", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/multiPolygon.geojson")
+		mp, err := DecodeMultiPolygon(j)
+		So(err, ShouldBeNil)
+		So(len(mp.Polygons), ShouldEqual, 2)
+		So(len(mp.Polygons[1].LineStrings), ShouldEqual, 2)
+		So(mp.Polygons[0].LineStrings[0].Points[2], ShouldResemble, &Point{3, 103})
+	})
+
+	Convey("Given geoJson geometryCollection, should return geometryCollection", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/geometryCollection.geojson")
+		gc, err := DecodeGeometryCollection(j)
+		So(err, ShouldBeNil)
+		So(len(gc.Geometries), ShouldEqual, 4)
+		So(gc.Geometries[0], ShouldResemble, &Point{0, 100})
+		So(gc.Geometries[1], ShouldResemble, NewLineString([]*Point{{0, 101}, {1, 102}}))
+		_, ok := gc.Geometries[2].(*Polygon)
+		So(ok, ShouldBeTrue)
+		So(gc.Geometries[3], ShouldResemble, NewGeometryCollection([]Geometry{}))
+	})
+
+	Convey("Given geometryCollection holding a feature, should return error", t, func() {
+		j := []byte(`{"type": "GeometryCollection", "geometries": [{"type": "Feature", "geometry": null}]}`)
+		gc, err := DecodeGeometryCollection(j)
+		So(gc, ShouldBeNil)
+		So(err.Error(), ShouldEqual, "geometryCollection can't hold a feature or featureCollection")
+	})
+
+	Convey("Given geoJson of another geometry type, should return error", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/lineString.geojson")
+		p, err := DecodePolygon(j)
+		So(p, ShouldBeNil)
+		So(err.Error(), ShouldEqual, "geometry is not of type polygon")
+	})
+
+	Convey("Given unknown geometry type, should return error", t, func() {
+		g, err := DecodeGeometry([]byte(`{"type": "InvalidGeometry"}`))
+		So(g, ShouldBeNil)
+		So(err.Error(), ShouldEqual, "unknown geometry type InvalidGeometry")
+	})
+
+	Convey("Given position with a single element, should return error", t, func() {
+		g, err := DecodeGeometry([]byte(`{"type": "Point", "coordinates": [1]}`))
+		So(g, ShouldBeNil)
+		So(err.Error(), ShouldEqual, "position should have at least two elements")
+	})
+
+	Convey("Given position with altitude, should ignore altitude", t, func() {
+		p, err := DecodePoint([]byte(`{"type": "Point", "coordinates": [1, 2, 3]}`))
+		So(err, ShouldBeNil)
+		So(p, ShouldResemble, &Point{2, 1})
+	})
+}
+
+func TestEncodeGeometry(t *testing.T) {
+	shouldRoundTrip := func(file string) {
+		j, _ := ioutil.ReadFile(file)
+		g, err := DecodeGeometry(j)
+		So(err, ShouldBeNil)
+		encoded, err := EncodeGeometry(g)
+		So(err, ShouldBeNil)
+
+		var expected, actual interface{}
+		json.Unmarshal(j, &expected)
+		json.Unmarshal(encoded, &actual)
+		So(actual, ShouldResemble, expected)
+	}
+
+	Convey("Given geometries, should encode them back into the same geoJson", t, func() {
+		shouldRoundTrip("./testdata/geoJsonEncoder/point.geojson")
+		shouldRoundTrip("./testdata/geoJsonEncoder/multiPoint.geojson")
+		shouldRoundTrip("./testdata/geoJsonEncoder/lineString.geojson")
+		shouldRoundTrip("./testdata/geoJsonEncoder/multiLineString.geojson")
+		shouldRoundTrip("./testdata/geoJsonEncoder/polygon.geojson")
+		shouldRoundTrip("./testdata/geoJsonEncoder/multiPolygon.geojson")
+		shouldRoundTrip("./testdata/geoJsonEncoder/geometryCollection.geojson")
+	})
+
+	Convey("Given a point, should encode it in lng, lat order", t, func() {
+		j, err := EncodePoint(NewPoint(22.5, -97.8))
+		So(err, ShouldBeNil)
+		So(string(j), ShouldEqual, `{"type":"Point","coordinates":[-97.8,22.5]}`)
+	})
+
+	Convey("Given an empty point, should encode empty coordinates and decode it back", t, func() {
+		j, err := EncodePoint(emptyPoint())
+		So(err, ShouldBeNil)
+		So(string(j), ShouldEqual, `{"type":"Point","coordinates":[]}`)
+		p, err := DecodePoint(j)
+		So(err, ShouldBeNil)
+		So(isEmptyPoint(p), ShouldBeTrue)
+	})
+
+	Convey("Given an empty lineString, should encode empty coordinates", t, func() {
+		j, err := EncodeLineString(NewLineString(nil))
+		So(err, ShouldBeNil)
+		So(string(j), ShouldEqual, `{"type":"LineString","coordinates":[]}`)
+	})
+}
+
+func TestFeatureJSON(t *testing.T) {
+	Convey("Given geoJson featureCollection, should decode features with id, properties and bbox", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/features.geojson")
+		fc := &FeatureCollection{}
+		err := json.Unmarshal(j, fc)
+		So(err, ShouldBeNil)
+		So(fc.BoundingBox, ShouldResemble, NewBBox(100, 0, 103, 3))
+		So(len(fc.Features), ShouldEqual, 3)
+
+		zone := fc.Features[0]
+		So(zone.ID, ShouldEqual, "zone-1")
+		So(zone.BoundingBox, ShouldResemble, NewBBox(100, 0, 101, 1))
+		So(zone.Properties["name"], ShouldEqual, "first zone")
+		So(zone.Properties["priority"], ShouldEqual, 1)
+		_, ok := zone.Geometry.(*Polygon)
+		So(ok, ShouldBeTrue)
+
+		depot := fc.Features[1]
+		So(depot.ID, ShouldEqual, 2)
+		So(depot.BoundingBox, ShouldBeNil)
+		So(depot.Geometry, ShouldResemble, &Point{3, 103})
+
+		empty := fc.Features[2]
+		So(empty.Geometry, ShouldBeNil)
+		So(empty.Properties, ShouldBeNil)
+	})
+
+	Convey("Given featureCollection, should encode it back into the same geoJson", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/features.geojson")
+		fc := &FeatureCollection{}
+		json.Unmarshal(j, fc)
+		encoded, err := json.Marshal(fc)
+		So(err, ShouldBeNil)
+
+		var expected, actual interface{}
+		json.Unmarshal(j, &expected)
+		json.Unmarshal(encoded, &actual)
+		So(actual, ShouldResemble, expected)
+	})
+
+	Convey("Given geoJson feature, DecodeGeometry should return feature", t, func() {
+		j, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/linestringInFeature.geojson")
+		g, err := DecodeGeometry(j)
+		So(err, ShouldBeNil)
+		f, ok := g.(*Feature)
+		So(ok, ShouldBeTrue)
+		So(f.Properties, ShouldResemble, map[string]interface{}{})
+		So(len(f.GetPoints()), ShouldEqual, 3)
+	})
+
+	Convey("Given a feature, should encode it with null geometry and properties", t, func() {
+		j, err := EncodeGeometry(NewFeature(nil, nil))
+		So(err, ShouldBeNil)
+		So(string(j), ShouldEqual, `{"type":"Feature","geometry":null,"properties":null}`)
+	})
+
+	Convey("Given an empty featureCollection, should encode empty features", t, func() {
+		j, err := json.Marshal(NewFeatureCollection(nil))
+		So(err, ShouldBeNil)
+		So(string(j), ShouldEqual, `{"type":"FeatureCollection","features":[]}`)
+	})
+
+	Convey("Given a feature with a feature as geometry, should return error", t, func() {
+		_, err := json.Marshal(NewFeature(NewFeature(nil, nil), nil))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Given geoJson of another type, should return error", t, func() {
+		err := json.Unmarshal([]byte(`{"type": "Point", "coordinates": [1, 2]}`), &Feature{})
+		So(err.Error(), ShouldEqual, "object is not of type feature")
+	})
+
+	Convey("Given invalid bbox, should return error", t, func() {
+		err := json.Unmarshal([]byte(`{"type": "Feature", "bbox": [1, 2], "geometry": null}`), &Feature{})
+		So(err.Error(), ShouldEqual, "bbox should have four or six elements")
+	})
+}
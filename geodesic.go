@@ -0,0 +1,275 @@
+package turfgo
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// EarthModel is the shape of the earth used to measure distances and bearings
+type EarthModel int32
+
+// EarthModel constants
+const (
+	// Sphere uses the Haversine formula on a sphere with the radii of the Unit table
+	Sphere EarthModel = iota
+	// WGS84 uses Vincenty's formulae on the WGS 84 ellipsoid, they are accurate to within 0.5 mm.
+	// Nearly antipodal points, for which Vincenty's inverse formula does not converge, are solved numerically.
+	WGS84
+)
+
+// WGS 84 ellipsoid
+const (
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+	wgs84B = wgs84A * (1 - wgs84F)
+	// wgs84MeanRadius is the mean radius (2a + b) / 3 of the ellipsoid, which converts meters to Degrees and Radians
+	wgs84MeanRadius = (2*wgs84A + wgs84B) / 3
+)
+
+const (
+	vincentyEpsilon       = 1e-12
+	vincentyMaxIterations = 200
+)
+
+// earthModel is the EarthModel used by Distance, Destination, Bearing and Along, it is read and written atomically
+var earthModel = Sphere
+
+// SetEarthModel sets the earth model used by Distance, Destination, Bearing and Along, and by every
+// function built on them. The default is Sphere. It is safe to call while other goroutines measure, but it
+// changes the results of every caller in the program, so it is best set once at startup. Code which needs a
+// given model should call its methods, like WGS84.Distance, instead.
+func SetEarthModel(model EarthModel) {
+	atomic.StoreInt32((*int32)(&earthModel), int32(model))
+}
+
+func currentEarthModel() EarthModel {
+	return EarthModel(atomic.LoadInt32((*int32)(&earthModel)))
+}
+
+// Distance calculates the distance between two points in the given unit on the earth model.
+func (m EarthModel) Distance(point1 *Point, point2 *Point, unit Unit) float64 {
+	if m == WGS84 {
+		distance, _ := vincentyInverse(point1, point2)
+		return fromMeters(distance, unit)
+	}
+	return haversineDistance(point1, point2, unit)
+}
+
+// Bearing finds the initial bearing from point1 to point2 on the earth model, in degrees between -180 and 180.
+func (m EarthModel) Bearing(point1, point2 *Point) float64 {
+	if m == WGS84 {
+		_, bearing := vincentyInverse(point1, point2)
+		return bearing
+	}
+	return sphericalBearing(point1, point2)
+}
+
+// Destination calculates the location of a destination point given a start point, distance and bearing in
+// degrees on the earth model.
+func (m EarthModel) Destination(start *Point, distance float64, bearing float64, unit Unit) *Point {
+	if m == WGS84 {
+		if distance < 0 {
+			distance, bearing = -distance, bearing+180
+		}
+		return vincentyDirect(start, toMeters(distance, unit), bearing)
+	}
+	return sphericalDestination(start, distance, bearing, unit)
+}
+
+// Along takes a line and returns a point at a specified distance along the line on the earth model.
+// Returns the last point if distance is more than the span of the line.
+func (m EarthModel) Along(lineString *LineString, distance float64, unit Unit) *Point {
+	travelled := float64(0)
+	points := lineString.GetPoints()
+	for i, point := range points {
+		if distance >= travelled && i == len(points)-1 {
+			break
+		} else if travelled >= distance {
+			overshot := distance - travelled
+			if overshot == 0 {
+				return point
+			}
+			bearing := m.Bearing(point, points[i-1])
+			direction := bearing - 180
+			interpolated := m.Destination(point, overshot, direction, unit)
+			return interpolated
+
+		} else {
+			t := m.Distance(points[i], points[i+1], unit)
+			travelled += t
+		}
+	}
+	return points[len(points)-1]
+}
+
+// fromMeters converts meters on the ellipsoid to unit, angles are measured on a sphere of the mean radius of the
+// ellipsoid rather than with the spherical radius of the Unit table
+func fromMeters(meters float64, unit Unit) float64 {
+	if unit == Degrees || unit == Radians {
+		return RadsToDistance(meters/wgs84MeanRadius, unit)
+	}
+	return ConvertDistance(meters, Meters, unit)
+}
+
+// toMeters is the inverse of fromMeters
+func toMeters(distance float64, unit Unit) float64 {
+	if unit == Degrees || unit == Radians {
+		return DistanceToRads(distance, unit) * wgs84MeanRadius
+	}
+	return ConvertDistance(distance, unit, Meters)
+}
+
+// vincentyInverse returns the distance in meters and the initial bearing in degrees between two points
+// on the WGS 84 ellipsoid. Reference: T. Vincenty, "Direct and Inverse Solutions of Geodesics on the
+// Ellipsoid with application of nested equations", Survey Review, vol XXIII no 176, 1975.
+func vincentyInverse(point1, point2 *Point) (float64, float64) {
+	distance, bearing, ok := vincenty(point1, point2)
+	if !ok {
+		return antipodalInverse(point1, point2)
+	}
+	return distance, bearing
+}
+
+// vincenty iterates Vincenty's inverse formula, ok is false when it does not converge
+func vincenty(point1, point2 *Point) (distance float64, bearing float64, ok bool) {
+	lat1, lng1 := DegreesToRads(point1.Lat, point1.Lng)
+	lat2, lng2 := DegreesToRads(point2.Lat, point2.Lng)
+	l := math.Remainder(lng2-lng1, 2*math.Pi)
+	u1 := math.Atan((1 - wgs84F) * math.Tan(lat1))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(lat2))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM, sinLambda, cosLambda float64
+	converged := false
+	for i := 0; i < vincentyMaxIterations; i++ {
+		sinLambda, cosLambda = math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		if sinSigma == 0 {
+			if cosSigma > 0 {
+				// coincident points
+				return 0, 0, true
+			}
+			break
+		}
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			// on the equatorial line cos2Alpha is 0
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda) > math.Pi {
+			break
+		}
+		if math.Abs(lambda-previous) < vincentyEpsilon {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return 0, 0, false
+	}
+
+	uSq := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+	distance = wgs84B * a * (sigma - deltaSigma)
+	bearing = math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+	return distance, RadsToDegree(bearing), true
+}
+
+// vincentyDirect returns the destination point on the WGS 84 ellipsoid for a start point, a distance in
+// meters and an initial bearing in degrees.
+func vincentyDirect(start *Point, distance float64, bearing float64) *Point {
+	lat1, lng1 := DegreesToRads(start.Lat, start.Lng)
+	sinAlpha1, cosAlpha1 := math.Sincos(DegreeToRads(bearing))
+	tanU1 := (1 - wgs84F) * math.Tan(lat1)
+	cosU1 := 1 / math.Sqrt(1+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	sigma1 := math.Atan2(tanU1, cosAlpha1)
+	sinAlpha := cosU1 * sinAlpha1
+	cos2Alpha := 1 - sinAlpha*sinAlpha
+	uSq := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+
+	sigma := distance / (wgs84B * a)
+	var sinSigma, cosSigma, cos2SigmaM float64
+	for i := 0; i < vincentyMaxIterations; i++ {
+		cos2SigmaM = math.Cos(2*sigma1 + sigma)
+		sinSigma, cosSigma = math.Sincos(sigma)
+		deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		previous := sigma
+		sigma = distance/(wgs84B*a) + deltaSigma
+		if math.Abs(sigma-previous) < vincentyEpsilon {
+			break
+		}
+	}
+	sinSigma, cosSigma = math.Sincos(sigma)
+	cos2SigmaM = math.Cos(2*sigma1 + sigma)
+
+	tmp := sinU1*sinSigma - cosU1*cosSigma*cosAlpha1
+	lat2 := math.Atan2(sinU1*cosSigma+cosU1*sinSigma*cosAlpha1, (1-wgs84F)*math.Hypot(sinAlpha, tmp))
+	lambda := math.Atan2(sinSigma*sinAlpha1, cosU1*cosSigma-sinU1*sinSigma*cosAlpha1)
+	c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+	l := lambda - (1-c)*wgs84F*sinAlpha*
+		(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+	return &Point{RadsToDegree(lat2), RadsToDegree(lng1 + l)}
+}
+
+// antipodalInverse solves the inverse problem for nearly antipodal points, where Vincenty's formula does
+// not converge. Every path between the points crosses the circle of points a quarter of the earth away
+// from point1, so the geodesic is the path through the point of that circle which minimises the sum of
+// distances to point1 and point2. Both legs are short enough for Vincenty's formula to converge.
+func antipodalInverse(point1, point2 *Point) (float64, float64) {
+	pathThrough := func(theta float64) float64 {
+		middle := sphericalDestination(point1, math.Pi/2, RadsToDegree(theta), Radians)
+		leg1, _, ok1 := vincenty(point1, middle)
+		leg2, _, ok2 := vincenty(middle, point2)
+		if !ok1 || !ok2 {
+			return math.Inf(1)
+		}
+		return leg1 + leg2
+	}
+
+	// sample the circle to find the neighbourhood of the shortest path, then refine it with a golden
+	// section search
+	const samples = 72
+	step := 2 * math.Pi / samples
+	best, bestDistance := 0.0, math.Inf(1)
+	for i := 0; i < samples; i++ {
+		if d := pathThrough(float64(i) * step); d < bestDistance {
+			best, bestDistance = float64(i)*step, d
+		}
+	}
+	ratio := (math.Sqrt(5) - 1) / 2
+	low, high := best-step, best+step
+	x1, x2 := high-ratio*(high-low), low+ratio*(high-low)
+	f1, f2 := pathThrough(x1), pathThrough(x2)
+	for high-low > vincentyEpsilon {
+		if f1 < f2 {
+			high, x2, f2 = x2, x1, f1
+			x1 = high - ratio*(high-low)
+			f1 = pathThrough(x1)
+		} else {
+			low, x1, f1 = x1, x2, f2
+			x2 = low + ratio*(high-low)
+			f2 = pathThrough(x2)
+		}
+	}
+	theta := (low + high) / 2
+	middle := sphericalDestination(point1, math.Pi/2, RadsToDegree(theta), Radians)
+	_, bearing, _ := vincenty(point1, middle)
+	return pathThrough(theta), bearing
+}
@@ -0,0 +1,115 @@
+package turfgo
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func dms(degrees, minutes, seconds float64) float64 {
+	if degrees < 0 {
+		return degrees - minutes/60 - seconds/3600
+	}
+	return degrees + minutes/60 + seconds/3600
+}
+
+// Flinders Peak and Buninyong, the example of Vincenty's paper as published by Geoscience Australia
+var flindersPeak = NewPoint(dms(-37, 57, 3.72030), dms(144, 25, 29.52440))
+var buninyong = NewPoint(dms(-37, 39, 10.15610), dms(143, 55, 35.38390))
+
+func TestWGS84(t *testing.T) {
+	Convey("Given two points, should calculate ellipsoidal distance and bearing", t, func() {
+		So(WGS84.Distance(flindersPeak, buninyong, Meters), ShouldAlmostEqual, 54972.271, 0.001)
+		So(WGS84.Bearing(flindersPeak, buninyong), ShouldAlmostEqual, dms(306, 52, 5.37)-360, 0.00001)
+	})
+
+	Convey("Given a point, distance and bearing, should calculate ellipsoidal destination", t, func() {
+		p := WGS84.Destination(flindersPeak, 54.972271, dms(306, 52, 5.37), Kilometers)
+		So(p.Lat, ShouldAlmostEqual, buninyong.Lat, 0.0000001)
+		So(p.Lng, ShouldAlmostEqual, buninyong.Lng, 0.0000001)
+
+		// a negative distance travels the reverse of the bearing, the reverse azimuth at Buninyong is 127°10'25.07"
+		back := WGS84.Destination(buninyong, -54.972271, dms(127, 10, 25.07)-180, Kilometers)
+		So(back.Lat, ShouldAlmostEqual, flindersPeak.Lat, 0.000001)
+		So(back.Lng, ShouldAlmostEqual, flindersPeak.Lng, 0.000001)
+	})
+
+	Convey("Given the same point twice, should return 0", t, func() {
+		So(WGS84.Distance(flindersPeak, flindersPeak, Meters), ShouldEqual, 0)
+	})
+
+	Convey("Given antipodal points on the equator, the geodesic should pass over the pole", t, func() {
+		d := WGS84.Distance(NewPoint(0, 0), NewPoint(0, 180), Meters)
+		So(d, ShouldAlmostEqual, 20003931.4586, 0.001)
+	})
+
+	Convey("Given nearly antipodal points, should find the geodesic", t, func() {
+		for _, p2 := range []*Point{NewPoint(0.5, 179.7), NewPoint(-0.3, 179.9), NewPoint(0.1, -179.5)} {
+			p1 := NewPoint(0, 0)
+			d := WGS84.Distance(p1, p2, Meters)
+			b := WGS84.Bearing(p1, p2)
+			So(d, ShouldBeLessThan, 20003931.4586)
+			destination := WGS84.Destination(p1, d, b, Meters)
+			So(destination.Lat, ShouldAlmostEqual, p2.Lat, 0.000001)
+			So(destination.Lng, ShouldAlmostEqual, p2.Lng, 0.000001)
+		}
+	})
+
+	Convey("Given a line, should return a point along it on the ellipsoid", t, func() {
+		line := NewLineString([]*Point{flindersPeak, buninyong})
+		p := WGS84.Along(line, 20, Kilometers)
+		So(WGS84.Distance(flindersPeak, p, Kilometers), ShouldAlmostEqual, 20, 0.000001)
+		So(WGS84.Distance(p, buninyong, Kilometers), ShouldAlmostEqual, 34.972271, 0.000001)
+		So(WGS84.Along(line, 100, Kilometers), ShouldEqual, buninyong)
+	})
+
+	Convey("Given angular units, should convert meters with the mean radius of the ellipsoid", t, func() {
+		meters := WGS84.Distance(flindersPeak, buninyong, Meters)
+		So(WGS84.Distance(flindersPeak, buninyong, Radians), ShouldAlmostEqual, meters/6371008.771, 1e-12)
+		So(WGS84.Distance(flindersPeak, buninyong, Degrees), ShouldAlmostEqual, RadsToDegree(meters/6371008.771), 1e-9)
+		bearing := WGS84.Bearing(flindersPeak, buninyong)
+		p := WGS84.Destination(flindersPeak, WGS84.Distance(flindersPeak, buninyong, Degrees), bearing, Degrees)
+		So(p.Lat, ShouldAlmostEqual, buninyong.Lat, 1e-9)
+		So(p.Lng, ShouldAlmostEqual, buninyong.Lng, 1e-9)
+	})
+
+	Convey("Given Sphere, should match the Haversine functions", t, func() {
+		So(Sphere.Distance(flindersPeak, buninyong, Meters), ShouldEqual, haversineDistance(flindersPeak, buninyong, Meters))
+		So(Sphere.Bearing(flindersPeak, buninyong), ShouldEqual, sphericalBearing(flindersPeak, buninyong))
+	})
+}
+
+func TestSetEarthModel(t *testing.T) {
+	Convey("Given WGS84 as earth model, package functions should use it", t, func() {
+		SetEarthModel(WGS84)
+		defer SetEarthModel(Sphere)
+		So(Distance(flindersPeak, buninyong, Meters), ShouldAlmostEqual, 54972.271, 0.001)
+		So(Bearing(flindersPeak, buninyong), ShouldAlmostEqual, dms(306, 52, 5.37)-360, 0.00001)
+		p := Destination(flindersPeak, 54972.271, dms(306, 52, 5.37), Meters)
+		So(p.Lat, ShouldAlmostEqual, buninyong.Lat, 0.0000001)
+	})
+
+	Convey("Given measurements in other goroutines, should change the earth model safely", t, func() {
+		done := make(chan bool)
+		go func() {
+			for i := 0; i < 100; i++ {
+				Distance(flindersPeak, buninyong, Meters)
+			}
+			done <- true
+		}()
+		SetEarthModel(WGS84)
+		SetEarthModel(Sphere)
+		<-done
+		So(currentEarthModel(), ShouldEqual, Sphere)
+	})
+
+	Convey("By default package functions should use a sphere", t, func() {
+		So(Distance(flindersPeak, buninyong, Meters), ShouldEqual, haversineDistance(flindersPeak, buninyong, Meters))
+	})
+}
+
+func BenchmarkWGS84Distance(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		testResultF = WGS84.Distance(flindersPeak, buninyong, Meters)
+	}
+}
@@ -0,0 +1,53 @@
+package turfgo
+
+// Tin takes points and returns a Triangulated Irregular Network, a FeatureCollection of the triangles of their
+// Delaunay triangulation as Polygons. Nil and empty points are ignored, as well as duplicated points. The
+// collection is empty when there are less than 3 points or when all of them are on the same line.
+func Tin(points []*Point) *FeatureCollection {
+	return tin(points, func(a, b, c int) map[string]interface{} {
+		return nil
+	})
+}
+
+// TinWithZ is Tin for point features, like turf's tin. If zProperty is not empty, the properties a, b and c of
+// every triangle are the values of this property for its three points. Nil features and features which are not
+// points are ignored.
+func TinWithZ(points []*Feature, zProperty string) *FeatureCollection {
+	vertices := make([]*Point, len(points))
+	for i, feature := range points {
+		if feature != nil {
+			vertices[i], _ = feature.Geometry.(*Point)
+		}
+	}
+	return tin(vertices, func(a, b, c int) map[string]interface{} {
+		if zProperty == "" {
+			return nil
+		}
+		return map[string]interface{}{
+			"a": points[a].Properties[zProperty],
+			"b": points[b].Properties[zProperty],
+			"c": points[c].Properties[zProperty],
+		}
+	})
+}
+
+// tin triangulates points, properties returns the properties of a triangle from the indexes of its points
+func tin(points []*Point, properties func(a, b, c int) map[string]interface{}) *FeatureCollection {
+	indexes := []int{}
+	vertices := []vec{}
+	for i, point := range points {
+		if point != nil && !isEmptyPoint(point) {
+			indexes = append(indexes, i)
+			vertices = append(vertices, pointToVec(point))
+		}
+	}
+	d := newDelaunay(vertices)
+	triangles := []*Feature{}
+	for t := 0; t < len(d.triangles); t += 3 {
+		// the triangles of the triangulation are clockwise, rings are written counter-clockwise
+		a, b, c := indexes[d.triangles[t]], indexes[d.triangles[t+2]], indexes[d.triangles[t+1]]
+		ring := []*Point{points[a], points[b], points[c], points[a]}
+		triangles = append(triangles, NewFeature(NewPolygon([]*LineString{NewLineString(ring)}), properties(a, b, c)))
+	}
+	return NewFeatureCollection(triangles)
+}
@@ -0,0 +1,51 @@
+package turfgo
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTin(t *testing.T) {
+	elevation := func(lat, lng float64, z int) *Feature {
+		return NewFeature(NewPoint(lat, lng), map[string]interface{}{"elevation": z})
+	}
+
+	Convey("Given points, should return the triangles with the z values of their points", t, func() {
+		points := []*Feature{elevation(0, 0, 10), elevation(0, 2, 20), elevation(2, 2, 30), elevation(2, 0, 40),
+			elevation(1, 1, 50), NewFeature(NewLineString([]*Point{{0, 0}, {5, 5}}), nil)}
+		tin := TinWithZ(points, "elevation")
+		So(tin.Features, ShouldHaveLength, 4)
+		for _, triangle := range tin.Features {
+			ring := triangle.Geometry.(*Polygon).LineStrings[0].Points
+			So(ring, ShouldHaveLength, 4)
+			So(ring[0], ShouldEqual, ring[3])
+			// counter-clockwise
+			So(cross(pointToVec(ring[1]).sub(pointToVec(ring[0])), pointToVec(ring[2]).sub(pointToVec(ring[0]))), ShouldBeGreaterThan, 0)
+			// every triangle has the middle point
+			So(triangle.Properties["a"] == 50 || triangle.Properties["b"] == 50 || triangle.Properties["c"] == 50, ShouldBeTrue)
+			for i, key := range []string{"a", "b", "c"} {
+				for _, point := range points[:5] {
+					if point.Geometry == ring[i] {
+						So(triangle.Properties[key], ShouldEqual, point.Properties["elevation"])
+					}
+				}
+			}
+		}
+	})
+
+	Convey("Given points, should return triangles without properties", t, func() {
+		a, b, c := NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 0)
+		tin := Tin([]*Point{a, nil, b, NewPoint(math.NaN(), math.NaN()), c, NewPoint(0, 1)})
+		So(tin.Features, ShouldHaveLength, 1)
+		So(tin.Features[0].Properties, ShouldBeNil)
+		So(tin.Features[0].Geometry.(*Polygon).LineStrings[0].Points, ShouldResemble, []*Point{a, b, c, a})
+		So(TinWithZ([]*Feature{NewFeature(a, nil), nil, NewFeature(b, nil), NewFeature(c, nil)}, ""), ShouldResemble, tin)
+	})
+
+	Convey("Given collinear points, should return an empty collection", t, func() {
+		tin := TinWithZ([]*Feature{elevation(0, 0, 1), elevation(1, 1, 1), elevation(2, 2, 1)}, "elevation")
+		So(tin.Features, ShouldBeEmpty)
+	})
+}
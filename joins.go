@@ -3,7 +3,7 @@ package turfgo
 // Inside takes a Point and a Polygon or MultiPolygon and determines if the point resides
 // inside the polygon. The polygon can be convex or concave. The function accounts for holes.
 func Inside(point *Point, polygon PolygonI) bool {
-	polygons := polygon.getPolygons()
+	polygons := polygon.GetPolygons()
 	insidePoly := false
 	for i := 0; i < len(polygons) && !insidePoly; i++ {
 		// check if it is in the outer ring first
@@ -24,21 +24,86 @@ func Inside(point *Point, polygon PolygonI) bool {
 }
 
 // Within takes a set of points and a set of polygons and returns the points that fall within the polygons.
+// A point is returned once for every polygon it falls in, use PointsInPolygons to know which polygon it is.
 func Within(points []*Point, polygons []PolygonI) []*Point {
 	result := []*Point{}
-	for _, polygon := range polygons {
-		for _, point := range points {
-			if Inside(point, polygon) {
-				result = append(result, point)
+	for _, indices := range PointsInPolygons(points, polygons) {
+		for _, i := range indices {
+			result = append(result, points[i])
+		}
+	}
+	return result
+}
+
+// PointsInPolygons takes a set of points and a set of polygons and returns, for every polygon, the indices of
+// the points that fall within it in increasing order. The points are indexed in an RTree so each polygon is
+// only tested against the points within its bounds.
+func PointsInPolygons(points []*Point, polygons []PolygonI) [][]int {
+	tree := newPointTree(points)
+	result := make([][]int, len(polygons))
+	for i, polygon := range polygons {
+		result[i] = []int{}
+		for _, point := range searchPoints(tree, polygon.Bounds()) {
+			if Inside(point.Point, polygon) {
+				result[i] = append(result[i], point.index)
 			}
 		}
 	}
 	return result
 }
 
+// Tag takes point features and polygon features and returns a FeatureCollection of copies of the points,
+// where the property outField is set to the property field of the first polygon the point falls in, or to the
+// ID of the polygon if field is empty. Points outside the polygons and features which are not points are
+// copied unchanged, nil features stay nil and nil polygons are ignored.
+func Tag(points []*Feature, polygons []*Feature, field, outField string) *FeatureCollection {
+	locations := make([]*Point, len(points))
+	for i, feature := range points {
+		if feature == nil {
+			continue
+		}
+		if point, ok := feature.Geometry.(*Point); ok {
+			locations[i] = point
+		}
+	}
+	polygonsI := make([]PolygonI, len(polygons))
+	for i, polygon := range polygons {
+		polygonsI[i] = polygon
+	}
+	tags := make([]*Feature, len(points))
+	for i, indices := range PointsInPolygons(locations, polygonsI) {
+		for _, index := range indices {
+			if tags[index] == nil {
+				tags[index] = polygons[i]
+			}
+		}
+	}
+
+	features := make([]*Feature, len(points))
+	for i, feature := range points {
+		if feature == nil {
+			continue
+		}
+		properties := make(map[string]interface{}, len(feature.Properties)+1)
+		for key, value := range feature.Properties {
+			properties[key] = value
+		}
+		if polygon := tags[i]; polygon != nil {
+			if field == "" {
+				properties[outField] = polygon.ID
+			} else {
+				properties[outField] = polygon.Properties[field]
+			}
+		}
+		features[i] = &Feature{ID: feature.ID, Geometry: feature.Geometry, Properties: properties,
+			BoundingBox: feature.BoundingBox}
+	}
+	return NewFeatureCollection(features)
+}
+
 func inRing(point *Point, ring *LineString) bool {
 	isInside := false
-	ringPoints := ring.getPoints()
+	ringPoints := ring.GetPoints()
 	for i, j := 0, len(ringPoints)-1; i < len(ringPoints); j, i = i, i+1 {
 		xi, yi := ringPoints[i].Lng, ringPoints[i].Lat
 		xj, yj := ringPoints[j].Lng, ringPoints[j].Lat
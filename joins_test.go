@@ -162,4 +162,100 @@ func TestWithin(t *testing.T) {
 			So(result, ShouldResemble, []*Point{point1, point2, point3, point4, point5})
 		})
 	})
+
+	Convey("Given many points and overlapping polygons", t, func() {
+		Convey("Should return the points of each polygon in order, as often as they are covered", func() {
+			points := randomPoints(2000, 2)
+			points = append(points, points[7])
+			polygons := []PolygonI{
+				NewPolygon([]*LineString{NewLineString([]*Point{{-40, -40}, {-40, 60}, {50, 0}, {-40, -40}})}),
+				NewPolygon([]*LineString{NewLineString([]*Point{{-60, -100}, {-60, 100}, {60, 100}, {60, -100}, {-60, -100}}),
+					NewLineString([]*Point{{-10, -10}, {-10, 10}, {10, 10}, {10, -10}, {-10, -10}})}),
+			}
+			expected := []*Point{}
+			for _, polygon := range polygons {
+				for _, point := range points {
+					if Inside(point, polygon) {
+						expected = append(expected, point)
+					}
+				}
+			}
+			So(len(expected), ShouldBeGreaterThan, 500)
+			So(Within(points, polygons), ShouldResemble, expected)
+		})
+	})
+}
+
+func TestPointsInPolygons(t *testing.T) {
+	Convey("Given points and overlapping polygons, should return the indices of the points in every polygon", t, func() {
+		polygon1 := NewPolygon([]*LineString{NewLineString([]*Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}})})
+		polygon2 := NewPolygon([]*LineString{NewLineString([]*Point{{5, 5}, {5, 20}, {20, 20}, {20, 5}, {5, 5}})})
+		polygon3 := NewPolygon([]*LineString{NewLineString([]*Point{{50, 50}, {50, 60}, {60, 60}, {50, 50}})})
+		points := []*Point{{1, 1}, {7, 7}, {15, 15}, {30, 30}, nil, {7, 7}}
+		So(PointsInPolygons(points, []PolygonI{polygon1, polygon2, polygon3}), ShouldResemble,
+			[][]int{{0, 1, 5}, {1, 2, 5}, {}})
+		So(PointsInPolygons([]*Point{}, []PolygonI{polygon1}), ShouldResemble, [][]int{{}})
+		So(PointsInPolygons(points, []PolygonI{}), ShouldBeEmpty)
+	})
+}
+
+func TestTag(t *testing.T) {
+	Convey("Given point and polygon features, should tag the points with the first polygon they fall in", t, func() {
+		polygon1 := NewFeature(NewPolygon([]*LineString{NewLineString([]*Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}})}),
+			map[string]interface{}{"name": "first"})
+		polygon1.ID = 1
+		polygon2 := NewFeature(NewPolygon([]*LineString{NewLineString([]*Point{{5, 5}, {5, 20}, {20, 20}, {20, 5}, {5, 5}})}),
+			map[string]interface{}{"name": "second"})
+		polygon2.ID = "two"
+		point1 := NewFeature(NewPoint(1, 1), map[string]interface{}{"kind": "a"})
+		point2 := NewFeature(NewPoint(7, 7), nil)
+		point3 := NewFeature(NewPoint(15, 15), nil)
+		point4 := NewFeature(NewPoint(30, 30), map[string]interface{}{"name": "kept"})
+		line := NewFeature(NewLineString([]*Point{{1, 1}, {2, 2}}), nil)
+		points := []*Feature{point1, point2, point3, point4, line}
+
+		tagged := Tag(points, []*Feature{polygon1, polygon2}, "name", "name")
+		So(len(tagged.Features), ShouldEqual, 5)
+		So(tagged.Features[0].Properties, ShouldResemble, map[string]interface{}{"kind": "a", "name": "first"})
+		So(tagged.Features[1].Properties, ShouldResemble, map[string]interface{}{"name": "first"})
+		So(tagged.Features[2].Properties, ShouldResemble, map[string]interface{}{"name": "second"})
+		So(tagged.Features[3].Properties, ShouldResemble, map[string]interface{}{"name": "kept"})
+		So(tagged.Features[4].Properties, ShouldResemble, map[string]interface{}{})
+		So(tagged.Features[0].Geometry, ShouldEqual, point1.Geometry)
+		So(point1.Properties, ShouldResemble, map[string]interface{}{"kind": "a"})
+
+		byID := Tag(points, []*Feature{polygon1, polygon2}, "", "polygon")
+		So(byID.Features[1].Properties["polygon"], ShouldEqual, 1)
+		So(byID.Features[2].Properties["polygon"], ShouldEqual, "two")
+		_, ok := byID.Features[3].Properties["polygon"]
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Given nil point and polygon features, should keep the nil points and ignore the nil polygons", t, func() {
+		polygon := NewFeature(box(0, 0, 10, 10), map[string]interface{}{"name": "zone"})
+		point := NewFeature(NewPoint(1, 1), nil)
+		tagged := Tag([]*Feature{nil, point, nil}, []*Feature{nil, polygon}, "name", "zone")
+		So(tagged.Features, ShouldHaveLength, 3)
+		So(tagged.Features[0], ShouldBeNil)
+		So(tagged.Features[1].Properties, ShouldResemble, map[string]interface{}{"zone": "zone"})
+		So(tagged.Features[2], ShouldBeNil)
+	})
+}
+
+func BenchmarkTag(b *testing.B) {
+	points := []*Feature{}
+	for _, point := range randomPoints(10000, 1) {
+		points = append(points, NewFeature(point, nil))
+	}
+	polygons := []*Feature{}
+	for lat := -80.0; lat < 80; lat += 10 {
+		for lng := -180.0; lng < 180; lng += 10 {
+			ring := []*Point{{lat, lng}, {lat + 10, lng}, {lat + 10, lng + 10}, {lat, lng + 10}, {lat, lng}}
+			polygons = append(polygons, NewFeature(NewPolygon([]*LineString{NewLineString(ring)}), map[string]interface{}{"lat": lat}))
+		}
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Tag(points, polygons, "lat", "lat")
+	}
 }
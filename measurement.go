@@ -1,37 +1,22 @@
 package turfgo
 
 import (
+	"errors"
 	"math"
 )
 
 // Along takes a line and returns a point at a specified distance along the line.
 // Returns the last point if distance is more than the span of the line.
 func Along(lineString *LineString, distance float64, unit Unit) *Point {
-	travelled := float64(0)
-	points := lineString.getPoints()
-	for i, point := range points {
-		if distance >= travelled && i == len(points)-1 {
-			break
-		} else if travelled >= distance {
-			overshot := distance - travelled
-			if overshot == 0 {
-				return point
-			}
-			bearing := Bearing(point, points[i-1])
-			direction := bearing - 180
-			interpolated := Destination(point, overshot, direction, unit)
-			return interpolated
-
-		} else {
-			t := Distance(points[i], points[i+1], unit)
-			travelled += t
-		}
-	}
-	return points[len(points)-1]
+	return currentEarthModel().Along(lineString, distance, unit)
 }
 
 // Bearing takes two points and finds the geographic bearing between them.
 func Bearing(point1, point2 *Point) float64 {
+	return currentEarthModel().Bearing(point1, point2)
+}
+
+func sphericalBearing(point1, point2 *Point) float64 {
 	lat1, lng1 := DegreesToRads(point1.Lat, point1.Lng)
 	lat2, lng2 := DegreesToRads(point2.Lat, point2.Lng)
 	a := math.Sin(lng2-lng1) * math.Cos(lat2)
@@ -49,8 +34,13 @@ func Center(shapes ...Geometry) *Point {
 
 // Destination takes a Point and calculates the location of a destination point
 // given a distance in degrees, radians, miles, or kilometers; and bearing in
-// degrees. This uses the Haversine formula to account for global curvature.
+// degrees. This uses the Haversine formula to account for global curvature, or Vincenty's
+// formulae if the earth model is set to WGS84.
 func Destination(start *Point, distance float64, bearing float64, unit Unit) *Point {
+	return currentEarthModel().Destination(start, distance, bearing, unit)
+}
+
+func sphericalDestination(start *Point, distance float64, bearing float64, unit Unit) *Point {
 	r := DistanceToRads(distance, unit)
 	lat, lon := DegreesToRads(start.Lat, start.Lng)
 	bearingRad := DegreeToRads(bearing)
@@ -64,8 +54,13 @@ func Destination(start *Point, distance float64, bearing float64, unit Unit) *Po
 }
 
 // Distance calculates the distance between two points in degress, radians, miles, or
-// kilometers. This uses the Haversine formula to account for global curvature.
+// kilometers. This uses the Haversine formula to account for global curvature, or Vincenty's
+// formulae if the earth model is set to WGS84.
 func Distance(point1 *Point, point2 *Point, unit Unit) float64 {
+	return currentEarthModel().Distance(point1, point2, unit)
+}
+
+func haversineDistance(point1 *Point, point2 *Point, unit Unit) float64 {
 	dLat, dLng := DegreesToRads(point2.Lat-point1.Lat, point2.Lng-point1.Lng)
 	latRad1 := DegreeToRads(point1.Lat)
 	latRad2 := DegreeToRads(point2.Lat)
@@ -75,6 +70,108 @@ func Distance(point1 *Point, point2 *Point, unit Unit) float64 {
 	return RadsToDistance(c, unit)
 }
 
+// RhumbBearing takes two points and finds the bearing of the rhumb line between them, the line of
+// constant bearing crossing all meridians at the same angle. The bearing is in degrees between -180 and 180.
+// The shorter rhumb line is used when the points are on either side of the antimeridian.
+func RhumbBearing(point1, point2 *Point) float64 {
+	lat1, lng1 := DegreesToRads(point1.Lat, point1.Lng)
+	lat2, lng2 := DegreesToRads(point2.Lat, point2.Lng)
+	dLng := math.Remainder(lng2-lng1, 2*math.Pi)
+	dPsi := math.Log(math.Tan(lat2/2+math.Pi/4) / math.Tan(lat1/2+math.Pi/4))
+	return RadsToDegree(math.Atan2(dLng, dPsi))
+}
+
+// RhumbDestination takes a Point and calculates the location of a destination point travelling along a
+// rhumb line with the given bearing in degrees for the given distance. A latitude past a pole is reflected
+// back from it, as in turfjs. The longitude of the destination is continuous with the start, so it is outside
+// -180 and 180 when the antimeridian is crossed.
+func RhumbDestination(start *Point, distance float64, bearing float64, unit Unit) *Point {
+	delta := DistanceToRads(distance, unit)
+	lat1, lng1 := DegreesToRads(start.Lat, start.Lng)
+	theta := DegreeToRads(bearing)
+
+	dLat := delta * math.Cos(theta)
+	lat2 := lat1 + dLat
+	if lat2 > math.Pi/2 {
+		lat2 = math.Pi - lat2
+	} else if lat2 < -math.Pi/2 {
+		lat2 = -math.Pi - lat2
+	}
+	dLng := delta * math.Sin(theta) / rhumbStretch(lat1, lat2)
+
+	lng := math.Mod(RadsToDegree(lng1+dLng)+540, 360) - 180
+	if lng-start.Lng > 180 {
+		lng -= 360
+	} else if start.Lng-lng > 180 {
+		lng += 360
+	}
+	return &Point{RadsToDegree(lat2), lng}
+}
+
+// RhumbDistance calculates the distance between two points along a rhumb line in the given unit.
+// The shorter rhumb line is used when the points are on either side of the antimeridian.
+func RhumbDistance(point1 *Point, point2 *Point, unit Unit) float64 {
+	lat1, lng1 := DegreesToRads(point1.Lat, point1.Lng)
+	lat2, lng2 := DegreesToRads(point2.Lat, point2.Lng)
+	dLat := lat2 - lat1
+	dLng := math.Remainder(lng2-lng1, 2*math.Pi)
+	q := rhumbStretch(lat1, lat2)
+	return RadsToDistance(math.Sqrt(dLat*dLat+q*q*dLng*dLng), unit)
+}
+
+// rhumbStretch returns the ratio of the latitude difference to the difference of the mercator projected
+// latitudes, it is the cosine of the latitude on an east-west line
+func rhumbStretch(lat1, lat2 float64) float64 {
+	dPsi := math.Log(math.Tan(lat2/2+math.Pi/4) / math.Tan(lat1/2+math.Pi/4))
+	if math.Abs(dPsi) > 1e-11 {
+		return (lat2 - lat1) / dPsi
+	}
+	return math.Cos(lat1)
+}
+
+// Length takes a geometry and measures its length in the specified unit with Distance.
+// Lengths of lineStrings are summed, for polygons the perimeters of all
+// rings, holes included, are summed. Points have no length.
+func Length(geometry Geometry, unit Unit) float64 {
+	length := float64(0)
+	switch g := geometry.(type) {
+	case *Point, *MultiPoint:
+	case *LineString:
+		length = lineLength(g.Points, unit)
+	case *MultiLineString:
+		for _, lineString := range g.LineStrings {
+			length += lineLength(lineString.Points, unit)
+		}
+	case *GeometryCollection:
+		for _, member := range g.Geometries {
+			length += Length(member, unit)
+		}
+	case *Feature:
+		if g.Geometry != nil {
+			length = Length(g.Geometry, unit)
+		}
+	case *FeatureCollection:
+		for _, feature := range g.Features {
+			length += Length(feature, unit)
+		}
+	case PolygonI:
+		for _, polygon := range g.GetPolygons() {
+			for _, ring := range polygon.LineStrings {
+				length += lineLength(ring.Points, unit)
+			}
+		}
+	}
+	return length
+}
+
+func lineLength(points []*Point, unit Unit) float64 {
+	length := float64(0)
+	for i := 0; i < len(points)-1; i++ {
+		length += Distance(points[i], points[i+1], unit)
+	}
+	return length
+}
+
 // Bbox is an alias for Extent
 func Bbox(shapes ...Geometry) *BoundingBox {
 	return Extent(shapes...)
@@ -84,19 +181,32 @@ func Bbox(shapes ...Geometry) *BoundingBox {
 func Extent(geometries ...Geometry) *BoundingBox {
 	extent := NewInfiniteBBox()
 	for _, shape := range geometries {
-		for _, point := range shape.getPoints() {
-			if extent.West > point.Lng {
-				extent.West = point.Lng
-			}
-			if extent.South > point.Lat {
-				extent.South = point.Lat
-			}
-			if extent.East < point.Lng {
-				extent.East = point.Lng
-			}
-			if extent.North < point.Lat {
-				extent.North = point.Lat
-			}
+		bounds := shape.Bounds()
+		if bounds == nil {
+			continue
+		}
+		extent.West = math.Min(extent.West, bounds.West)
+		extent.South = math.Min(extent.South, bounds.South)
+		extent.East = math.Max(extent.East, bounds.East)
+		extent.North = math.Max(extent.North, bounds.North)
+	}
+	return extent
+}
+
+func pointsExtent(points []*Point) *BoundingBox {
+	extent := NewInfiniteBBox()
+	for _, point := range points {
+		if extent.West > point.Lng {
+			extent.West = point.Lng
+		}
+		if extent.South > point.Lat {
+			extent.South = point.Lat
+		}
+		if extent.East < point.Lng {
+			extent.East = point.Lng
+		}
+		if extent.North < point.Lat {
+			extent.North = point.Lat
 		}
 	}
 	return extent
@@ -119,3 +229,48 @@ func Expand(distance float64, unit Unit, geometries ...Geometry) *BoundingBox {
 	topEdge := Destination(topRight, distance, 0, unit)
 	return Extent(leftEdge, bottomEdge, rightEge, topEdge)
 }
+
+// earthRadiusMeters is the WGS 84 equatorial radius, used by Area as in turfjs
+const earthRadiusMeters = 6378137
+
+// Area takes a Polygon or MultiPolygon and returns its area on a spherical earth, in square units.
+// Holes are subtracted from the area. Allowed units are Kilometers, Meters, Centimeters, Miles, Yards,
+// Feet, Inches, the result can be passed to ConvertArea.
+func Area(geometry PolygonI, unit Unit) (float64, error) {
+	factor, ok := areaFactors[unit]
+	if !ok {
+		return -1, errors.New("invalid unit")
+	}
+	area := float64(0)
+	for _, polygon := range geometry.GetPolygons() {
+		for i, ring := range polygon.LineStrings {
+			if i == 0 {
+				area += math.Abs(ringArea(ring))
+			} else {
+				area -= math.Abs(ringArea(ring))
+			}
+		}
+	}
+	return area * factor, nil
+}
+
+// ringArea calculates the signed area of a ring in square meters, it is negative for
+// counter-clockwise rings. Reference: Chamberlain, R. and Duquette, W., "Some Algorithms for
+// Polygons on a Sphere", JPL Publication 07-03, 2007.
+func ringArea(ring *LineString) float64 {
+	points := ring.Points
+	n := len(points)
+	if n > 0 && isEqualLocation(points[0], points[n-1]) {
+		n--
+	}
+	if n < 3 {
+		return 0
+	}
+	area := float64(0)
+	for i := 0; i < n; i++ {
+		previous := points[(i+n-1)%n]
+		next := points[(i+1)%n]
+		area += (DegreeToRads(next.Lng) - DegreeToRads(previous.Lng)) * math.Sin(DegreeToRads(points[i].Lat))
+	}
+	return area * earthRadiusMeters * earthRadiusMeters / 2
+}
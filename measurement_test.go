@@ -5,6 +5,7 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 	"io/ioutil"
+	"math"
 )
 
 var units = [4]Unit{Kilometers, Miles, Degrees, Radians}
@@ -266,6 +267,7 @@ func TestExtent(t *testing.T) {
 		{polygon, NewBBox(100, 0, 101, 1)},
 		{multiLineString, NewBBox(100, 0, 103, 3)},
 		{multiPoly, NewBBox(100, 0, 103, 3)},
+		{NewGeometryCollection([]Geometry{point, lineString}), NewBBox(102, -10, 130, 4)},
 	}
 
 	Convey("Given different type of shapes, should return bounding box", t, func() {
@@ -308,6 +310,9 @@ func TestCenter(t *testing.T) {
 		point := Center(lineString, point5)
 		So(point.Lat, ShouldEqual, 35.4661725)
 		So(point.Lng, ShouldEqual, -97.5125065)
+
+		collection := NewGeometryCollection([]Geometry{lineString, point5})
+		So(Center(collection), ShouldResemble, point)
 	})
 }
 
@@ -355,3 +360,237 @@ func BenchmarkExpand(b *testing.B) {
 		testResultBbox = Expand(20, Kilometers, lineString)
 	}
 }
+
+func TestArea(t *testing.T) {
+	square := NewPolygon([]*LineString{NewLineString([]*Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}})})
+	hole := NewLineString([]*Point{{0.2, 0.2}, {0.8, 0.2}, {0.8, 0.8}, {0.2, 0.8}, {0.2, 0.2}})
+	squareWithHole := NewPolygon([]*LineString{square.LineStrings[0], hole})
+
+	Convey("Given a polygon, should return its area", t, func() {
+		area, err := Area(square, Meters)
+		So(err, ShouldBeNil)
+		So(area, ShouldAlmostEqual, 12391399902.071106, 0.01)
+
+		area, err = Area(square, Kilometers)
+		So(err, ShouldBeNil)
+		So(area, ShouldAlmostEqual, 12391.399902071106, 0.00001)
+	})
+
+	Convey("Given a polygon with hole, should subtract the hole", t, func() {
+		area, err := Area(squareWithHole, Meters)
+		So(err, ShouldBeNil)
+		So(area, ShouldAlmostEqual, 12391399902.071106-4460940201.526029, 0.01)
+	})
+
+	Convey("Given a multiPolygon, should return area of all polygons", t, func() {
+		area, err := Area(NewMultiPolygon([]*Polygon{square, squareWithHole}), Meters)
+		So(err, ShouldBeNil)
+		So(area, ShouldAlmostEqual, 2*12391399902.071106-4460940201.526029, 0.01)
+	})
+
+	Convey("Given rings in any winding order, should return the same area", t, func() {
+		reversed := NewPolygon([]*LineString{NewLineString([]*Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}})})
+		area, _ := Area(square, Meters)
+		reversedArea, _ := Area(reversed, Meters)
+		So(reversedArea, ShouldAlmostEqual, area, 0.01)
+	})
+
+	Convey("Given an area, should be convertible with ConvertArea", t, func() {
+		area, _ := Area(square, Meters)
+		converted, err := ConvertArea(area, Meters, Miles)
+		So(err, ShouldBeNil)
+		areaInMiles, _ := Area(square, Miles)
+		So(areaInMiles, ShouldAlmostEqual, converted)
+	})
+
+	Convey("Given a unit which is not an area unit, should return error", t, func() {
+		area, err := Area(square, Degrees)
+		So(area, ShouldEqual, -1)
+		So(err.Error(), ShouldEqual, "invalid unit")
+	})
+}
+
+func BenchmarkArea(b *testing.B) {
+	polygon := NewPolygon([]*LineString{NewLineString(append(longRoute.Points, longRoute.Points[0]))})
+	for n := 0; n < b.N; n++ {
+		testResultF, _ = Area(polygon, Meters)
+	}
+}
+
+func TestLength(t *testing.T) {
+	point1 := NewPoint(38.878605, -77.031669)
+	point2 := NewPoint(38.881946, -77.029609)
+	point3 := NewPoint(38.884084, -77.020339)
+	lineString := NewLineString([]*Point{point1, point2, point3})
+	expected := Distance(point1, point2, Kilometers) + Distance(point2, point3, Kilometers)
+
+	ring := NewLineString([]*Point{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}})
+	hole := NewLineString([]*Point{{0.2, 0.2}, {0.8, 0.2}, {0.8, 0.8}, {0.2, 0.8}, {0.2, 0.2}})
+	polygon := NewPolygon([]*LineString{ring, hole})
+
+	Convey("Given a lineString, should return sum of its segments", t, func() {
+		So(Length(lineString, Kilometers), ShouldAlmostEqual, expected)
+		So(Length(lineString, Kilometers), ShouldAlmostEqual, 1.2493371708948, 0.0000001)
+	})
+
+	Convey("Given a multiLineString, should return sum of all lines", t, func() {
+		multiLineString := NewMultiLineString([]*LineString{lineString, lineString})
+		So(Length(multiLineString, Kilometers), ShouldAlmostEqual, 2*expected)
+	})
+
+	Convey("Given a polygon, should return perimeter of all rings", t, func() {
+		ringLength := Distance(NewPoint(0, 0), NewPoint(0, 1), Miles)*3 + Distance(NewPoint(1, 1), NewPoint(1, 0), Miles)
+		holeLength := Distance(NewPoint(0.2, 0.2), NewPoint(0.8, 0.2), Miles)*2 +
+			Distance(NewPoint(0.8, 0.2), NewPoint(0.8, 0.8), Miles) + Distance(NewPoint(0.2, 0.8), NewPoint(0.2, 0.2), Miles)
+		So(Length(polygon, Miles), ShouldAlmostEqual, ringLength+holeLength, 0.0000001)
+		So(Length(NewMultiPolygon([]*Polygon{polygon, polygon}), Miles), ShouldAlmostEqual, 2*(ringLength+holeLength), 0.0000001)
+	})
+
+	Convey("Given points, should return 0", t, func() {
+		So(Length(point1, Kilometers), ShouldEqual, 0)
+		So(Length(NewMultiPoint([]*Point{point1, point2}), Kilometers), ShouldEqual, 0)
+		So(Length(NewLineString(nil), Kilometers), ShouldEqual, 0)
+	})
+
+	Convey("Given features and collections, should return length of their geometries", t, func() {
+		So(Length(NewFeature(lineString, nil), Kilometers), ShouldAlmostEqual, expected)
+		fc := NewFeatureCollection([]*Feature{NewFeature(lineString, nil), NewFeature(nil, nil)})
+		So(Length(fc, Kilometers), ShouldAlmostEqual, expected)
+		gc := NewGeometryCollection([]Geometry{point1, lineString, lineString})
+		So(Length(gc, Kilometers), ShouldAlmostEqual, 2*expected)
+	})
+}
+
+func BenchmarkLength(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		testResultF = Length(longRoute, Miles)
+	}
+}
+
+// Published rhumb line values. The turf.js values come from the examples of the rhumbBearing and rhumbDistance
+// docs, and from the pair1 fixture of turf-rhumb-bearing. The movable-type values come from the worked examples
+// of the rhumb lines section of www.movable-type.co.uk/scripts/latlong.html, by Chris Veness, which turf's rhumb
+// functions are ported from. Distances are compared in radians, times the radius of the source, because both
+// sources use the 6371 km mean radius while the Unit table uses 6373 km.
+var (
+	turfRhumbFrom   = NewPoint(39.984, -75.343)
+	turfRhumbTo     = NewPoint(39.123, -75.534)
+	turfRadiusMiles = 6371008.8 / 1609.344
+	plymouth        = NewPoint(dms(50, 21, 59), -dms(4, 8, 2))
+	boston          = NewPoint(dms(42, 21, 4), -dms(71, 2, 27))
+	dover           = NewPoint(dms(51, 7, 32), dms(1, 20, 17))
+	calais          = NewPoint(dms(50, 57, 48), dms(1, 51, 9))
+)
+
+// acrossAntimeridian moves two points east or west, with their longitudes wrapped into [-180, 180], so that the
+// antimeridian passes half way between them
+func acrossAntimeridian(point1, point2 *Point) (*Point, *Point) {
+	shift := 180 - (point1.Lng+point2.Lng)/2
+	return NewPoint(point1.Lat, math.Remainder(point1.Lng+shift, 360)),
+		NewPoint(point2.Lat, math.Remainder(point2.Lng+shift, 360))
+}
+
+func TestRhumbBearing(t *testing.T) {
+
+	type bearingTest struct {
+		point1 *Point
+		point2 *Point
+		result float64
+	}
+
+	testValues := []bearingTest{
+		// turf rhumbBearing docs
+		{turfRhumbFrom, turfRhumbTo, -170.29417535572546},
+		// turf-rhumb-bearing pair1 fixture, initial and final bearing
+		{NewPoint(45, -75), NewPoint(60, 20), 75.28061364784332},
+		{NewPoint(60, 20), NewPoint(45, -75), -104.71938635215668},
+	}
+
+	Convey("Given two points, should calculate rhumb bearing between them", t, func() {
+		for _, tt := range testValues {
+			So(RhumbBearing(tt.point1, tt.point2), ShouldAlmostEqual, tt.result, 1e-10)
+		}
+		// movable-type: 260°07′38″
+		So(RhumbBearing(plymouth, boston)+360, ShouldAlmostEqual, dms(260, 7, 38), 0.5/3600)
+	})
+
+	Convey("Given points across the antimeridian, should calculate the published bearings", t, func() {
+		for _, tt := range testValues {
+			point1, point2 := acrossAntimeridian(tt.point1, tt.point2)
+			So(point1.Lng*point2.Lng, ShouldBeLessThan, 0)
+			So(RhumbBearing(point1, point2), ShouldAlmostEqual, tt.result, 1e-9)
+		}
+		from, to := acrossAntimeridian(plymouth, boston)
+		So(RhumbBearing(from, to)+360, ShouldAlmostEqual, dms(260, 7, 38), 0.5/3600)
+	})
+}
+
+func TestRhumbDestination(t *testing.T) {
+
+	Convey("Given a start point, distance and bearing, should return the published rhumb destination", t, func() {
+		// movable-type: 40.23 km on 116°38′10″ from Dover reaches Calais
+		dest := RhumbDestination(dover, 40.23/6371, dms(116, 38, 10), Radians)
+		So(dest.Lat, ShouldAlmostEqual, calais.Lat, 1.0/3600)
+		So(dest.Lng, ShouldAlmostEqual, calais.Lng, 1.0/3600)
+
+		// turf docs: back along the published bearing and distance
+		distance := 60.35331130430885 / turfRadiusMiles
+		dest = RhumbDestination(turfRhumbFrom, distance, -170.29417535572546, Radians)
+		So(dest.Lat, ShouldAlmostEqual, turfRhumbTo.Lat, 1e-9)
+		So(dest.Lng, ShouldAlmostEqual, turfRhumbTo.Lng, 1e-9)
+	})
+
+	Convey("Given a start point near the antimeridian, the longitude should stay continuous with the start", t, func() {
+		from, to := acrossAntimeridian(dover, calais)
+		dest := RhumbDestination(from, 40.23/6371, dms(116, 38, 10), Radians)
+		So(dest.Lat, ShouldAlmostEqual, calais.Lat, 1.0/3600)
+		So(dest.Lng, ShouldAlmostEqual, to.Lng+360, 1.0/3600)
+
+		dest = RhumbDestination(to, 40.23/6371, dms(116, 38, 10)-180, Radians)
+		So(dest.Lat, ShouldAlmostEqual, dover.Lat, 1.0/3600)
+		So(dest.Lng, ShouldAlmostEqual, from.Lng-360, 1.0/3600)
+	})
+
+	Convey("Given a distance past the pole, should come back down on the other side", t, func() {
+		dest := RhumbDestination(NewPoint(89, 0), 2, 0, Degrees)
+		So(dest.Lat, ShouldAlmostEqual, 89, 1e-9)
+	})
+}
+
+func BenchmarkRhumbDestination(b *testing.B) {
+	b.StopTimer()
+	p := NewPoint(39.984, -75.343)
+	b.StartTimer()
+	for n := 0; n < b.N; n++ {
+		testResultP = RhumbDestination(p, 45.34, 120.5, Miles)
+	}
+}
+
+func TestRhumbDistance(t *testing.T) {
+
+	Convey("Given two points, should calculate the published rhumb distance between them", t, func() {
+		// turf rhumbDistance docs: 60.35331130430885 miles
+		So(RhumbDistance(turfRhumbFrom, turfRhumbTo, Radians)*turfRadiusMiles, ShouldAlmostEqual, 60.35331130430885, 1e-9)
+		So(RhumbDistance(turfRhumbTo, turfRhumbFrom, Radians)*turfRadiusMiles, ShouldAlmostEqual, 60.35331130430885, 1e-9)
+		// movable-type: 5198 km
+		So(RhumbDistance(plymouth, boston, Radians)*6371, ShouldAlmostEqual, 5198, 0.5)
+		// the Unit table radius
+		So(RhumbDistance(turfRhumbFrom, turfRhumbTo, Miles), ShouldAlmostEqual,
+			RhumbDistance(turfRhumbFrom, turfRhumbTo, Radians)*3960, 1e-9)
+	})
+
+	Convey("Given points across the antimeridian, should calculate the published rhumb distance", t, func() {
+		from, to := acrossAntimeridian(turfRhumbFrom, turfRhumbTo)
+		So(RhumbDistance(from, to, Radians)*turfRadiusMiles, ShouldAlmostEqual, 60.35331130430885, 1e-9)
+		So(RhumbDistance(to, from, Radians)*turfRadiusMiles, ShouldAlmostEqual, 60.35331130430885, 1e-9)
+		from, to = acrossAntimeridian(plymouth, boston)
+		So(RhumbDistance(from, to, Radians)*6371, ShouldAlmostEqual, 5198, 0.5)
+	})
+}
+
+func BenchmarkRhumbDistance(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		testResultF = RhumbDistance(&Point{39.984, -75.343},
+			&Point{39.123, -75.534}, Miles)
+	}
+}
@@ -134,3 +134,117 @@ func isAnyBaseAngleObtuse(point *Point, start *Point, end *Point) bool {
 	}
 	return false
 }
+
+// Kinks returns the points where a LineString, MultiLineString, Polygon or MultiPolygon intersects itself. The
+// lines and rings of a geometry are checked against each other too, every point is returned once. Features and
+// collections are checked as a whole, points have no kinks.
+func Kinks(geometry Geometry) []*Point {
+	kinks := []*Point{}
+	seen := map[vec]bool{}
+	partIntersections(lineParts(geometry), func(_, _, _, _ int, points []vec, _ bool) {
+		for _, p := range points {
+			if !seen[p] {
+				seen[p] = true
+				kinks = append(kinks, vecToPoint(p))
+			}
+		}
+	})
+	return kinks
+}
+
+// LineIntersect returns the points where the lines and polygon boundaries of two geometries meet. Touching end
+// points are intersections, and collinear overlaps add their two ends. Every point is returned once, in the
+// order of the segments of a.
+func LineIntersect(a, b Geometry) []*Point {
+	edges, others := partEdges(lineParts(a)), partEdges(lineParts(b))
+	rects := make([]rect, len(others))
+	for i, edge := range others {
+		rects[i] = segmentRect(edge[0], edge[1])
+	}
+	tree := newRectIndex(rects)
+	intersections := []*Point{}
+	seen := map[vec]bool{}
+	for _, edge := range edges {
+		tree.search(segmentRect(edge[0], edge[1]), func(j int) bool {
+			intersectEdges(edge, others[j], 0, func(_, _ float64, p vec) {
+				if !seen[p] {
+					seen[p] = true
+					intersections = append(intersections, vecToPoint(p))
+				}
+			})
+			return true
+		})
+	}
+	return intersections
+}
+
+// Unkink splits the self intersecting polygons of a Polygon or MultiPolygon into simple polygons, the areas
+// covered an odd number of times are kept. Polygons without kinks are returned as they are.
+func Unkink(polygon PolygonI) []*Polygon {
+	polygons := []*Polygon{}
+	for _, p := range polygon.GetPolygons() {
+		if len(Kinks(p)) == 0 {
+			polygons = append(polygons, p)
+		} else if unkinked := makeValidPolygon(p); unkinked != nil {
+			polygons = append(polygons, unkinked.GetPolygons()...)
+		}
+	}
+	return polygons
+}
+
+// lineParts returns the lines and polygon rings of a geometry
+func lineParts(geometry Geometry) []part {
+	parts := []part{}
+	switch g := geometry.(type) {
+	case *LineString:
+		parts = append(parts, linePart(g, false))
+	case *MultiLineString:
+		for _, line := range g.LineStrings {
+			parts = append(parts, linePart(line, false))
+		}
+	case *Polygon, *MultiPolygon:
+		for _, polygon := range g.(PolygonI).GetPolygons() {
+			for _, ring := range polygon.LineStrings {
+				parts = append(parts, linePart(ring, true))
+			}
+		}
+	case *GeometryCollection:
+		for _, geometry := range g.Geometries {
+			parts = append(parts, lineParts(geometry)...)
+		}
+	case *Feature:
+		if g != nil {
+			parts = lineParts(g.Geometry)
+		}
+	case *FeatureCollection:
+		for _, feature := range g.Features {
+			parts = append(parts, lineParts(feature)...)
+		}
+	}
+	return parts
+}
+
+// linePart returns the distinct consecutive vertices of a line, lines ending where they start are closed
+func linePart(line *LineString, ring bool) part {
+	vertices := []vec{}
+	if line != nil {
+		for _, point := range cleanPoints(line.Points) {
+			vertices = append(vertices, pointToVec(point))
+		}
+	}
+	if n := len(vertices); n > 1 && vertices[0] == vertices[n-1] {
+		vertices, ring = vertices[:n-1], true
+	}
+	return part{vertices, ring && len(vertices) > 2}
+}
+
+// partEdges returns the segments of parts
+func partEdges(parts []part) [][2]vec {
+	edges := [][2]vec{}
+	for _, p := range parts {
+		for i := 0; i < p.segments(); i++ {
+			edges = append(edges, [2]vec{p.vertices[i], p.vertices[(i+1)%len(p.vertices)]})
+		}
+	}
+	return edges
+}
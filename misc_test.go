@@ -158,3 +158,135 @@ func TestTriangularProjection(t *testing.T) {
 		So(index, ShouldEqual, 0)
 	})
 }
+
+func TestKinks(t *testing.T) {
+	Convey("Given a line crossing itself, should return the crossings", t, func() {
+		So(Kinks(xyLine(0, 0, 10, 10, 10, 0, 0, 10)), ShouldResemble, []*Point{xyPoint(5, 5)})
+		So(Kinks(xyLine(0, 0, 10, 0, 10, 10, 5, 10, 5, -5)), ShouldResemble, []*Point{xyPoint(5, 0)})
+	})
+
+	Convey("Given simple lines, should return no kinks", t, func() {
+		So(Kinks(xyLine(0, 0, 10, 0, 10, 10, 0, 10)), ShouldBeEmpty)
+		So(Kinks(xyLine(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)), ShouldBeEmpty)
+		So(Kinks(xyLine(0, 0, 10, 0, 10, 0, 10, 10)), ShouldBeEmpty)
+		So(Kinks(NewLineString([]*Point{})), ShouldBeEmpty)
+		So(Kinks(xyPoint(1, 1)), ShouldBeEmpty)
+	})
+
+	Convey("Given a closed line touching its start, should return the touching point", t, func() {
+		So(Kinks(xyLine(0, 0, 10, 0, 10, 10, 0, 0, 0, 10, 0, 0)), ShouldResemble, []*Point{xyPoint(0, 0)})
+	})
+
+	Convey("Given lines crossing each other, should return the crossings", t, func() {
+		lines := NewMultiLineString([]*LineString{xyLine(0, 0, 10, 10), xyLine(0, 10, 10, 0), xyLine(0, 5, 10, 5)})
+		So(Kinks(lines), ShouldResemble, []*Point{xyPoint(5, 5)})
+	})
+
+	Convey("Given a bowtie polygon, should return its crossing", t, func() {
+		bowtie := NewPolygon([]*LineString{xyLine(0, 0, 10, 10, 10, 0, 0, 10, 0, 0)})
+		So(Kinks(bowtie), ShouldResemble, []*Point{xyPoint(5, 5)})
+		So(Kinks(box(0, 0, 10, 10)), ShouldBeEmpty)
+		holes := box(0, 0, 10, 10, [4]float64{5, 5, 15, 15})
+		So(len(Kinks(holes)), ShouldEqual, 2)
+		So(Kinks(NewMultiPolygon([]*Polygon{bowtie, box(20, 0, 30, 10)})), ShouldResemble, []*Point{xyPoint(5, 5)})
+	})
+}
+
+func TestUnkink(t *testing.T) {
+	Convey("Given a bowtie polygon, should split it into two triangles", t, func() {
+		bowtie := NewPolygon([]*LineString{xyLine(0, 0, 10, 10, 10, 0, 0, 10, 0, 0)})
+		polygons := Unkink(bowtie)
+		So(len(polygons), ShouldEqual, 2)
+		for _, polygon := range polygons {
+			So(planarArea(polygon), ShouldAlmostEqual, 25)
+			So(Kinks(polygon), ShouldBeEmpty)
+			So(Validate(polygon), ShouldBeEmpty)
+		}
+	})
+
+	Convey("Given a ring crossing itself twice, should split it into simple polygons", t, func() {
+		ring := xyLine(0, 0, 30, 10, 30, 0, 0, 10, 0, 0)
+		wrapped := NewPolygon([]*LineString{xyLine(0, 0, 10, 10, 20, 0, 30, 10, 30, 0, 20, 10, 10, 0, 0, 10, 0, 0)})
+		for _, polygon := range []*Polygon{NewPolygon([]*LineString{ring}), wrapped} {
+			unkinked := Unkink(polygon)
+			So(len(unkinked), ShouldBeGreaterThanOrEqualTo, 2)
+			for _, p := range unkinked {
+				So(Validate(p), ShouldBeEmpty)
+			}
+		}
+	})
+
+	Convey("Given simple polygons, should return them unchanged", t, func() {
+		a, b := box(0, 0, 10, 10), box(20, 0, 30, 10)
+		So(Unkink(a), ShouldResemble, []*Polygon{a})
+		So(Unkink(NewMultiPolygon([]*Polygon{a, b})), ShouldResemble, []*Polygon{a, b})
+	})
+}
+
+func TestLineIntersect(t *testing.T) {
+	Convey("Given crossing lines, should return the crossings", t, func() {
+		So(LineIntersect(xyLine(0, 0, 10, 10), xyLine(0, 10, 10, 0)), ShouldResemble, []*Point{xyPoint(5, 5)})
+		zigzag := xyLine(0, 0, 10, 10, 20, 0, 30, 10)
+		So(LineIntersect(zigzag, xyLine(-5, 5, 35, 5)), ShouldResemble,
+			[]*Point{xyPoint(5, 5), xyPoint(15, 5), xyPoint(25, 5)})
+	})
+
+	Convey("Given lines touching at their ends, should return the touching points", t, func() {
+		So(LineIntersect(xyLine(0, 0, 10, 0), xyLine(10, 0, 10, 10)), ShouldResemble, []*Point{xyPoint(10, 0)})
+		So(LineIntersect(xyLine(0, 0, 10, 0), xyLine(5, 0, 5, 10)), ShouldResemble, []*Point{xyPoint(5, 0)})
+	})
+
+	Convey("Given collinear overlapping lines, should return the ends of the overlap", t, func() {
+		So(LineIntersect(xyLine(0, 0, 10, 0), xyLine(5, 0, 15, 0)), ShouldResemble, []*Point{xyPoint(5, 0), xyPoint(10, 0)})
+		So(LineIntersect(xyLine(0, 0, 20, 0), xyLine(5, 0, 15, 0)), ShouldResemble, []*Point{xyPoint(5, 0), xyPoint(15, 0)})
+	})
+
+	Convey("Given disjoint lines, should return no intersections", t, func() {
+		So(LineIntersect(xyLine(0, 0, 10, 0), xyLine(0, 1, 10, 1)), ShouldBeEmpty)
+		So(LineIntersect(xyLine(0, 0, 10, 0), xyPoint(5, 0)), ShouldBeEmpty)
+		So(LineIntersect(nil, xyLine(0, 0, 10, 0)), ShouldBeEmpty)
+	})
+
+	Convey("Given polygons, should intersect their boundaries", t, func() {
+		So(LineIntersect(box(0, 0, 10, 10), xyLine(5, -5, 5, 5)), ShouldResemble, []*Point{xyPoint(5, 0)})
+		So(LineIntersect(box(0, 0, 10, 10), xyLine(2, 2, 8, 8)), ShouldBeEmpty)
+		So(len(LineIntersect(box(0, 0, 10, 10), box(5, 5, 15, 15))), ShouldEqual, 2)
+		withHole := box(0, 0, 10, 10, [4]float64{4, 4, 6, 6})
+		So(len(LineIntersect(withHole, xyLine(5, -5, 5, 15))), ShouldEqual, 4)
+	})
+
+	Convey("Given features and collections, should intersect all their lines", t, func() {
+		lines := NewFeatureCollection([]*Feature{NewFeature(xyLine(0, 10, 10, 0), nil),
+			NewFeature(NewGeometryCollection([]Geometry{xyLine(0, 5, 10, 5)}), nil)})
+		So(LineIntersect(xyLine(0, 0, 10, 10), lines), ShouldResemble, []*Point{xyPoint(5, 5)})
+	})
+
+	Convey("Given a long route, should find the same crossings as checking every segment", t, func() {
+		closure := NewLineString([]*Point{NewPoint(37.9, -79.4), NewPoint(37.9, -77.3)})
+		expected := map[Point]bool{}
+		for i := 1; i < len(longRoute.Points); i++ {
+			for _, p := range LineIntersect(NewLineString(longRoute.Points[i-1:i+1]), closure) {
+				expected[*p] = true
+			}
+		}
+		found := map[Point]bool{}
+		for _, p := range LineIntersect(longRoute, closure) {
+			found[*p] = true
+		}
+		So(expected, ShouldNotBeEmpty)
+		So(found, ShouldResemble, expected)
+	})
+}
+
+func BenchmarkLineIntersect(b *testing.B) {
+	closure := NewLineString([]*Point{NewPoint(37.9, -79.4), NewPoint(37.9, -77.3)})
+	for n := 0; n < b.N; n++ {
+		LineIntersect(longRoute, closure)
+	}
+}
+
+func BenchmarkKinks(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		Kinks(longRoute)
+	}
+}
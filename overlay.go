@@ -0,0 +1,579 @@
+package turfgo
+
+import (
+	"math"
+	"sort"
+)
+
+// vec is a point of the plane used by the planar algorithms, x is the longitude or easting and y the
+// latitude or northing
+type vec struct {
+	x, y float64
+}
+
+func (v vec) add(o vec) vec {
+	return vec{v.x + o.x, v.y + o.y}
+}
+
+func (v vec) sub(o vec) vec {
+	return vec{v.x - o.x, v.y - o.y}
+}
+
+func (v vec) scale(f float64) vec {
+	return vec{v.x * f, v.y * f}
+}
+
+func (v vec) length() float64 {
+	return math.Hypot(v.x, v.y)
+}
+
+func cross(a, b vec) float64 {
+	return a.x*b.y - a.y*b.x
+}
+
+func dot(a, b vec) float64 {
+	return a.x*b.x + a.y*b.y
+}
+
+func pointToVec(p *Point) vec {
+	return vec{p.Lng, p.Lat}
+}
+
+func vecToPoint(v vec) *Point {
+	return &Point{v.y, v.x}
+}
+
+// rect is an axis aligned rectangle of the plane
+type rect struct {
+	minX, minY, maxX, maxY float64
+}
+
+func segmentRect(a, b vec) rect {
+	return rect{math.Min(a.x, b.x), math.Min(a.y, b.y), math.Max(a.x, b.x), math.Max(a.y, b.y)}
+}
+
+func ringRect(ring []vec) rect {
+	r := rect{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, v := range ring {
+		r = r.extend(rect{v.x, v.y, v.x, v.y})
+	}
+	return r
+}
+
+func (r rect) extend(o rect) rect {
+	return rect{math.Min(r.minX, o.minX), math.Min(r.minY, o.minY), math.Max(r.maxX, o.maxX), math.Max(r.maxY, o.maxY)}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// ringIndex answers point in polygon queries with the even-odd rule for rings of several polygons
+type ringIndex struct {
+	edges  [][2]vec
+	owners []int
+	tree   *RTree
+}
+
+// newRingIndex indexes rings, owners gives the polygon of every ring
+func newRingIndex(rings [][]vec, owners []int) *ringIndex {
+	index := &ringIndex{}
+	rects := []rect{}
+	for i, ring := range rings {
+		for k := range ring {
+			a, b := ring[k], ring[(k+1)%len(ring)]
+			index.edges = append(index.edges, [2]vec{a, b})
+			index.owners = append(index.owners, owners[i])
+			rects = append(rects, segmentRect(a, b))
+		}
+	}
+	index.tree = newRectIndex(rects)
+	return index
+}
+
+// contains tells if p is inside any of the polygons
+func (index *ringIndex) contains(p vec) bool {
+	crossings := []int{}
+	index.tree.search(rect{p.x, p.y, math.Inf(1), p.y}, func(i int) bool {
+		a, b := index.edges[i][0], index.edges[i][1]
+		if (a.y > p.y) != (b.y > p.y) && p.x < (b.x-a.x)*(p.y-a.y)/(b.y-a.y)+a.x {
+			crossings = append(crossings, index.owners[i])
+		}
+		return true
+	})
+	sort.Ints(crossings)
+	for i := 0; i < len(crossings); {
+		k := i
+		for k < len(crossings) && crossings[k] == crossings[i] {
+			k++
+		}
+		if (k-i)%2 == 1 {
+			return true
+		}
+		i = k
+	}
+	return false
+}
+
+// convexIndex answers point in polygon queries for a set of counter-clockwise convex polygons
+type convexIndex struct {
+	polygons [][]vec
+	tree     *RTree
+}
+
+func newConvexIndex(polygons [][]vec) *convexIndex {
+	rects := make([]rect, len(polygons))
+	for i, polygon := range polygons {
+		rects[i] = ringRect(polygon)
+	}
+	return &convexIndex{polygons, newRectIndex(rects)}
+}
+
+// contains tells if p is strictly inside any of the polygons
+func (index *convexIndex) contains(p vec) bool {
+	found := false
+	index.tree.search(rect{p.x, p.y, p.x, p.y}, func(i int) bool {
+		found = inConvex(index.polygons[i], p)
+		return !found
+	})
+	return found
+}
+
+func inConvex(polygon []vec, p vec) bool {
+	for k := range polygon {
+		a, b := polygon[k], polygon[(k+1)%len(polygon)]
+		if cross(b.sub(a), p.sub(a)) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// overlayGraph holds the vertices of an overlay, vertices closer than tolerance are merged
+type overlayGraph struct {
+	tolerance float64
+	vertices  []vec
+	ids       map[[2]int64]int
+}
+
+func (g *overlayGraph) vertex(v vec) int {
+	key := [2]int64{int64(math.Floor(v.x/g.tolerance + 0.5)), int64(math.Floor(v.y/g.tolerance + 0.5))}
+	if id, ok := g.ids[key]; ok {
+		return id
+	}
+	g.vertices = append(g.vertices, v)
+	g.ids[key] = len(g.vertices) - 1
+	return len(g.vertices) - 1
+}
+
+type split struct {
+	t float64
+	v vec
+}
+
+// overlay returns the polygons bounding the region of the plane where inside is true, as rings without the
+// closing vertex. Outer rings are counter-clockwise and come first, holes are clockwise. edges must contain
+// every part of the boundary of the region, they may cross and overlap each other. Edges are split where they
+// meet into a planar graph, inside is tested once for every face of the graph and the edges between faces
+// inside and outside the region are kept. Vertices closer than tolerance are merged.
+func overlay(edges [][2]vec, inside func(vec) bool, tolerance float64) [][][]vec {
+	g := &overlayGraph{tolerance: tolerance, ids: map[[2]int64]int{}}
+	pieces := splitEdges(g, edges)
+
+	// half edges 2i and 2i+1 are the two directions of piece i, every half edge bounds the face on its left
+	halfEdges := make([][2]int, 2*len(pieces))
+	for i, piece := range pieces {
+		halfEdges[2*i], halfEdges[2*i+1] = piece, [2]int{piece[1], piece[0]}
+	}
+	faces := traceCycles(g, halfEdges)
+	faceOf := make([]int, len(halfEdges))
+	inFace := make([]bool, len(faces))
+	for f, face := range faces {
+		for _, h := range face {
+			faceOf[h] = f
+		}
+		inFace[f] = inside(facePoint(g, halfEdges, face, 100*tolerance))
+	}
+	boundary := [][2]int{}
+	for h, halfEdge := range halfEdges {
+		if inFace[faceOf[h]] && !inFace[faceOf[h^1]] {
+			boundary = append(boundary, halfEdge)
+		}
+	}
+	return assemblePolygons(g, boundary)
+}
+
+// assemblePolygons joins directed edges with the region on their left into polygons, slivers thinner than the
+// tolerance of the graph are dropped
+func assemblePolygons(g *overlayGraph, boundary [][2]int) [][][]vec {
+	outers, holes := [][]vec{}, [][]vec{}
+	for _, cycle := range traceCycles(g, boundary) {
+		ids := make([]int, len(cycle))
+		for i, e := range cycle {
+			ids[i] = boundary[e][0]
+		}
+		for _, simple := range splitPinches(ids) {
+			ring := make([]vec, len(simple))
+			for i, id := range simple {
+				ring[i] = g.vertices[id]
+			}
+			ring = removeCollinear(ring, g.tolerance)
+			if len(ring) < 3 {
+				continue
+			}
+			area := signedArea(ring)
+			if math.Abs(area) <= g.tolerance*ringLength(ring) {
+				continue
+			}
+			if area > 0 {
+				outers = append(outers, ring)
+			} else {
+				holes = append(holes, ring)
+			}
+		}
+	}
+	return nestHoles(outers, holes, 100*g.tolerance)
+}
+
+// splitPinches splits a cycle of vertices passing more than once through a vertex into simple rings, so a hole
+// touching its shell at a vertex comes out as a hole rather than as a notch of the shell
+func splitPinches(ids []int) [][]int {
+	rings := [][]int{}
+	stack := []int{}
+	position := map[int]int{}
+	for _, id := range ids {
+		if p, ok := position[id]; ok {
+			rings = append(rings, append([]int{}, stack[p:]...))
+			for _, removed := range stack[p+1:] {
+				delete(position, removed)
+			}
+			stack = stack[:p+1]
+			continue
+		}
+		position[id] = len(stack)
+		stack = append(stack, id)
+	}
+	return append(rings, stack)
+}
+
+// facePoint returns a point inside the face on the left of a cycle of half edges, next to its longest edge
+func facePoint(g *overlayGraph, halfEdges [][2]int, face []int, sample float64) vec {
+	ring := make([]vec, len(face))
+	longest := 0
+	for i, h := range face {
+		ring[i] = g.vertices[halfEdges[h][0]]
+		if segmentLength(g, halfEdges[h]) > segmentLength(g, halfEdges[face[longest]]) {
+			longest = i
+		}
+	}
+	// keep the point inside thin faces
+	if area := signedArea(ring); area > 0 {
+		sample = math.Min(sample, area/ringLength(ring))
+	}
+	a, b := g.vertices[halfEdges[face[longest]][0]], g.vertices[halfEdges[face[longest]][1]]
+	d := b.sub(a)
+	return a.add(b).scale(0.5).add(vec{-d.y, d.x}.scale(sample / d.length()))
+}
+
+func segmentLength(g *overlayGraph, edge [2]int) float64 {
+	return g.vertices[edge[1]].sub(g.vertices[edge[0]]).length()
+}
+
+func ringLength(ring []vec) float64 {
+	length := float64(0)
+	for i := range ring {
+		length += ring[(i+1)%len(ring)].sub(ring[i]).length()
+	}
+	return length
+}
+
+// splitEdges splits edges where they cross, touch or overlap and returns the distinct pieces
+func splitEdges(g *overlayGraph, edges [][2]vec) [][2]int {
+	rects := make([]rect, len(edges))
+	for i, edge := range edges {
+		r := segmentRect(edge[0], edge[1])
+		rects[i] = rect{r.minX - g.tolerance, r.minY - g.tolerance, r.maxX + g.tolerance, r.maxY + g.tolerance}
+	}
+	tree := newRectIndex(rects)
+	splits := make([][]split, len(edges))
+	for i, edge := range edges {
+		tree.search(rects[i], func(j int) bool {
+			if j > i {
+				intersectEdges(edge, edges[j], g.tolerance, func(t, u float64, v vec) {
+					splits[i] = append(splits[i], split{t, v})
+					splits[j] = append(splits[j], split{u, v})
+				})
+			}
+			return true
+		})
+	}
+
+	seen := map[[2]int]bool{}
+	pieces := [][2]int{}
+	for i, edge := range edges {
+		points := append(splits[i], split{0, edge[0]}, split{1, edge[1]})
+		sort.Slice(points, func(a, b int) bool { return points[a].t < points[b].t })
+		previous := g.vertex(points[0].v)
+		for _, s := range points[1:] {
+			current := g.vertex(s.v)
+			if current == previous {
+				continue
+			}
+			key := [2]int{previous, current}
+			if current < previous {
+				key = [2]int{current, previous}
+			}
+			if !seen[key] {
+				seen[key] = true
+				pieces = append(pieces, [2]int{previous, current})
+			}
+			previous = current
+		}
+	}
+	return pieces
+}
+
+// intersectEdges calls found with the position along both edges of every point where they meet, end points
+// closer than tolerance to the other edge included
+func intersectEdges(e, f [2]vec, tolerance float64, found func(t, u float64, v vec)) {
+	for _, end := range f {
+		if t, ok := onSegment(end, e[0], e[1], tolerance); ok {
+			u, _ := onSegment(end, f[0], f[1], tolerance)
+			found(t, u, end)
+		}
+	}
+	for _, end := range e {
+		if u, ok := onSegment(end, f[0], f[1], tolerance); ok {
+			t, _ := onSegment(end, e[0], e[1], tolerance)
+			found(t, u, end)
+		}
+	}
+	r, s := e[1].sub(e[0]), f[1].sub(f[0])
+	denominator := cross(r, s)
+	if denominator == 0 {
+		return
+	}
+	diff := f[0].sub(e[0])
+	t, u := cross(diff, s)/denominator, cross(diff, r)/denominator
+	if t > 0 && t < 1 && u > 0 && u < 1 {
+		found(t, u, e[0].add(r.scale(t)))
+	}
+}
+
+// part is a line, or a ring when closed, which has a segment from its last vertex back to the first
+type part struct {
+	vertices []vec
+	closed   bool
+}
+
+func (p part) segments() int {
+	if p.closed {
+		return len(p.vertices)
+	}
+	return len(p.vertices) - 1
+}
+
+// partIntersections calls found for every two segments of the parts which meet, with the distinct points where
+// they meet and whether they cross each other. Consecutive segments of a part are skipped when they only share
+// their common vertex, which is left out of the points otherwise. Points must match exactly to meet.
+func partIntersections(parts []part, found func(partA, segmentA, partB, segmentB int, points []vec, crossing bool)) {
+	type segment struct {
+		part, index int
+	}
+	segments := []segment{}
+	edges := [][2]vec{}
+	rects := []rect{}
+	for p, part := range parts {
+		for i := 0; i < part.segments(); i++ {
+			a, b := part.vertices[i], part.vertices[(i+1)%len(part.vertices)]
+			segments = append(segments, segment{p, i})
+			edges = append(edges, [2]vec{a, b})
+			rects = append(rects, segmentRect(a, b))
+		}
+	}
+	tree := newRectIndex(rects)
+	for i, s := range segments {
+		tree.search(rects[i], func(j int) bool {
+			if j <= i {
+				return true
+			}
+			t := segments[j]
+			adjacent, shared := false, vec{}
+			if s.part == t.part {
+				if t.index == s.index+1 {
+					adjacent, shared = true, edges[i][1]
+				} else if parts[s.part].closed && s.index == 0 && t.index == parts[s.part].segments()-1 {
+					adjacent, shared = true, edges[i][0]
+				}
+			}
+			points := []vec{}
+			crossing := false
+			intersectEdges(edges[i], edges[j], 0, func(a, b float64, p vec) {
+				if a > 0 && a < 1 && b > 0 && b < 1 {
+					crossing = true
+				}
+				if adjacent && p == shared {
+					return
+				}
+				for _, q := range points {
+					if q == p {
+						return
+					}
+				}
+				points = append(points, p)
+			})
+			if len(points) > 0 {
+				found(s.part, s.index, t.part, t.index, points, crossing)
+			}
+			return true
+		})
+	}
+}
+
+// onSegment returns the position of p along the segment ab if p is closer than tolerance to it
+func onSegment(p, a, b vec, tolerance float64) (float64, bool) {
+	d := b.sub(a)
+	lengthSq := dot(d, d)
+	if lengthSq == 0 {
+		return 0, p.sub(a).length() <= tolerance
+	}
+	t := dot(p.sub(a), d) / lengthSq
+	if t < 0 || t > 1 {
+		return t, false
+	}
+	return t, a.add(d.scale(t)).sub(p).length() <= tolerance
+}
+
+// traceCycles joins directed edges into cycles of edge indexes. At a vertex the cycle turns to the first edge
+// clockwise from the edge it arrived with, which follows the boundary of the face on the left of the edges and
+// keeps apart cycles touching at a vertex.
+func traceCycles(g *overlayGraph, edges [][2]int) [][]int {
+	outgoing := map[int][]int{}
+	for i, edge := range edges {
+		outgoing[edge[0]] = append(outgoing[edge[0]], i)
+	}
+	used := make([]bool, len(edges))
+	cycles := [][]int{}
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		cycle := []int{}
+		e := start
+		for {
+			used[e] = true
+			cycle = append(cycle, e)
+			from, to := g.vertices[edges[e][0]], g.vertices[edges[e][1]]
+			back := math.Atan2(from.y-to.y, from.x-to.x)
+			next, best := -1, math.Inf(1)
+			for _, c := range outgoing[edges[e][1]] {
+				d := g.vertices[edges[c][1]].sub(to)
+				turn := back - math.Atan2(d.y, d.x)
+				for turn <= 0 {
+					turn += 2 * math.Pi
+				}
+				if turn < best {
+					next, best = c, turn
+				}
+			}
+			if next == start {
+				cycles = append(cycles, cycle)
+				break
+			}
+			if next == -1 || used[next] {
+				// only reached when the edges don't form closed cycles
+				break
+			}
+			e = next
+		}
+	}
+	return cycles
+}
+
+// removeCollinear removes the vertices of a ring lying on the line through their neighbours
+func removeCollinear(ring []vec, tolerance float64) []vec {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		result := []vec{}
+		for i, v := range ring {
+			previous, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+			if len(result) > 0 {
+				previous = result[len(result)-1]
+			}
+			d := next.sub(previous)
+			length := d.length()
+			if length > 0 && math.Abs(cross(d, v.sub(previous)))/length <= tolerance &&
+				dot(v.sub(previous), d) > 0 && dot(next.sub(v), d) > 0 {
+				changed = true
+				continue
+			}
+			result = append(result, v)
+		}
+		ring = result
+	}
+	return ring
+}
+
+// signedArea returns the area of a ring, positive when it is counter-clockwise
+func signedArea(ring []vec) float64 {
+	area := float64(0)
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%len(ring)]
+		area += cross(a, b)
+	}
+	return area / 2
+}
+
+// nestHoles puts every hole in the smallest outer ring around it. The region on the left of a hole is inside the
+// result, so an outer ring always encloses it, unless this ring was dropped as a sliver: holes outside every
+// outer ring are dropped then, rather than turned into outer rings which would add the region they enclose.
+func nestHoles(outers, holes [][]vec, sample float64) [][][]vec {
+	sort.Slice(outers, func(i, j int) bool { return signedArea(outers[i]) < signedArea(outers[j]) })
+	polygons := make([][][]vec, len(outers))
+	indexes := make([]*ringIndex, len(outers))
+	for i, outer := range outers {
+		polygons[i] = [][]vec{outer}
+		indexes[i] = newRingIndex([][]vec{outer}, []int{0})
+	}
+	for _, hole := range holes {
+		// a point just outside the hole, inside the ring around it
+		a, b := hole[0], hole[1]
+		d := b.sub(a)
+		p := a.add(b).scale(0.5).add(vec{-d.y, d.x}.scale(sample / d.length()))
+		for i, index := range indexes {
+			if index.contains(p) {
+				polygons[i] = append(polygons[i], hole)
+				break
+			}
+		}
+	}
+	return polygons
+}
+
+// planarPolygons converts the result of an overlay to a Polygon or MultiPolygon, it returns nil when there is
+// no polygon
+func planarPolygons(polygons [][][]vec, toPoint func(vec) *Point) PolygonI {
+	result := []*Polygon{}
+	for _, rings := range polygons {
+		lineStrings := []*LineString{}
+		for _, ring := range rings {
+			points := make([]*Point, 0, len(ring)+1)
+			for _, v := range ring {
+				points = append(points, toPoint(v))
+			}
+			points = append(points, toPoint(ring[0]))
+			lineStrings = append(lineStrings, NewLineString(points))
+		}
+		result = append(result, NewPolygon(lineStrings))
+	}
+	switch len(result) {
+	case 0:
+		return nil
+	case 1:
+		return result[0]
+	}
+	return NewMultiPolygon(result)
+}
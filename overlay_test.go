@@ -0,0 +1,243 @@
+package turfgo
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// vecRing builds a ring without closing vertex from x, y pairs
+func vecRing(coordinates ...float64) []vec {
+	ring := []vec{}
+	for i := 0; i+1 < len(coordinates); i += 2 {
+		ring = append(ring, vec{coordinates[i], coordinates[i+1]})
+	}
+	return ring
+}
+
+// rectRing returns the ring of box without closing vertex
+func rectRing(west, south, east, north float64) []vec {
+	ring := []vec{}
+	for _, point := range box(west, south, east, north).LineStrings[0].Points[:4] {
+		ring = append(ring, pointToVec(point))
+	}
+	return ring
+}
+
+func ringEdges(rings ...[]vec) [][2]vec {
+	edges := [][2]vec{}
+	for _, ring := range rings {
+		for k, v := range ring {
+			edges = append(edges, [2]vec{v, ring[(k+1)%len(ring)]})
+		}
+	}
+	return edges
+}
+
+// evenOdd runs an overlay of rings with the even-odd rule, every ring is its own polygon when union is true
+func evenOdd(union bool, rings ...[]vec) [][][]vec {
+	owners := make([]int, len(rings))
+	if union {
+		for i := range owners {
+			owners[i] = i
+		}
+	}
+	return overlay(ringEdges(rings...), newRingIndex(rings, owners).contains, 1e-9)
+}
+
+func polygonsArea(polygons [][][]vec) float64 {
+	area := float64(0)
+	for _, polygon := range polygons {
+		for _, ring := range polygon {
+			area += signedArea(ring)
+		}
+	}
+	return area
+}
+
+func newTestGraph(tolerance float64) *overlayGraph {
+	return &overlayGraph{tolerance: tolerance, ids: map[[2]int64]int{}}
+}
+
+func TestIntersectEdges(t *testing.T) {
+	intersections := func(e, f [2]vec, tolerance float64) []vec {
+		points := []vec{}
+		intersectEdges(e, f, tolerance, func(_, _ float64, v vec) {
+			points = append(points, v)
+		})
+		return points
+	}
+
+	Convey("Given crossing edges, should return the crossing", t, func() {
+		So(intersections([2]vec{{0, 0}, {10, 10}}, [2]vec{{0, 10}, {10, 0}}, 0), ShouldResemble, []vec{{5, 5}})
+	})
+
+	Convey("Given edges touching at an end, should return the end", t, func() {
+		So(intersections([2]vec{{0, 0}, {10, 0}}, [2]vec{{5, 0}, {5, 10}}, 0), ShouldResemble, []vec{{5, 0}})
+	})
+
+	Convey("Given collinear overlapping edges, should return the ends of the overlap", t, func() {
+		So(intersections([2]vec{{0, 0}, {10, 0}}, [2]vec{{5, 0}, {15, 0}}, 0), ShouldResemble, []vec{{5, 0}, {10, 0}})
+		So(intersections([2]vec{{0, 0}, {10, 0}}, [2]vec{{12, 0}, {15, 0}}, 0), ShouldBeEmpty)
+	})
+
+	Convey("Given an end within tolerance of an edge, should return it", t, func() {
+		So(intersections([2]vec{{0, 0}, {10, 0}}, [2]vec{{5, 1e-10}, {5, 10}}, 1e-9), ShouldResemble, []vec{{5, 1e-10}})
+		So(intersections([2]vec{{0, 0}, {10, 0}}, [2]vec{{5, 1e-8}, {5, 10}}, 1e-9), ShouldBeEmpty)
+	})
+
+	Convey("Given parallel edges, should return nothing", t, func() {
+		So(intersections([2]vec{{0, 0}, {10, 0}}, [2]vec{{0, 1}, {10, 1}}, 0), ShouldBeEmpty)
+	})
+}
+
+func TestSplitEdges(t *testing.T) {
+	Convey("Given collinear overlapping edges, should return each piece once", t, func() {
+		g := newTestGraph(1e-9)
+		pieces := splitEdges(g, [][2]vec{{{0, 0}, {10, 0}}, {{5, 0}, {15, 0}}, {{15, 0}, {0, 0}}})
+		So(len(g.vertices), ShouldEqual, 4)
+		So(len(pieces), ShouldEqual, 3)
+	})
+
+	Convey("Given vertices within tolerance, should merge them", t, func() {
+		g := newTestGraph(1e-6)
+		pieces := splitEdges(g, [][2]vec{{{0, 0}, {10, 0}}, {{10 + 1e-8, 1e-8}, {10, 10}}})
+		So(len(g.vertices), ShouldEqual, 3)
+		So(pieces, ShouldResemble, [][2]int{{0, 1}, {1, 2}})
+	})
+
+	Convey("Given zero length edges, should split the edges they lie on and add no piece", t, func() {
+		g := newTestGraph(1e-9)
+		pieces := splitEdges(g, [][2]vec{{{0, 0}, {10, 0}}, {{5, 0}, {5, 0}}, {{20, 20}, {20, 20}}})
+		So(len(pieces), ShouldEqual, 2)
+		So(g.vertices[pieces[0][1]], ShouldResemble, vec{5, 0})
+	})
+
+	Convey("Given crossing edges, should split both", t, func() {
+		g := newTestGraph(1e-9)
+		So(len(splitEdges(g, [][2]vec{{{0, 0}, {10, 10}}, {{0, 10}, {10, 0}}})), ShouldEqual, 4)
+	})
+}
+
+func TestTraceCycles(t *testing.T) {
+	Convey("Given rings touching at a vertex, should keep their cycles apart", t, func() {
+		g := newTestGraph(1e-9)
+		edges := [][2]int{}
+		for _, ring := range [][]vec{rectRing(0, 0, 10, 10), rectRing(10, 10, 20, 20)} {
+			for k, v := range ring {
+				edges = append(edges, [2]int{g.vertex(v), g.vertex(ring[(k+1)%len(ring)])})
+			}
+		}
+		cycles := traceCycles(g, edges)
+		So(len(cycles), ShouldEqual, 2)
+		So(len(cycles[0]), ShouldEqual, 4)
+		So(len(cycles[1]), ShouldEqual, 4)
+	})
+}
+
+func TestOverlay(t *testing.T) {
+	Convey("Given rectangles sharing an edge, should merge them without the collinear vertices", t, func() {
+		polygons := evenOdd(true, rectRing(0, 0, 10, 10), rectRing(10, 0, 20, 10), rectRing(5, 10, 15, 20))
+		So(len(polygons), ShouldEqual, 1)
+		So(len(polygons[0]), ShouldEqual, 1)
+		So(len(polygons[0][0]), ShouldEqual, 8)
+		So(signedArea(polygons[0][0]), ShouldAlmostEqual, 300)
+	})
+
+	Convey("Given collinear overlapping edges, should merge the rectangles", t, func() {
+		polygons := evenOdd(true, rectRing(0, 0, 10, 10), rectRing(5, 0, 15, 10))
+		So(polygons, ShouldResemble, [][][]vec{{rectRing(0, 0, 15, 10)}})
+	})
+
+	Convey("Given a hole touching the shell at one point, should keep it a hole", t, func() {
+		polygons := evenOdd(false, rectRing(0, 0, 10, 10), vecRing(0, 5, 5, 7, 5, 3))
+		So(len(polygons), ShouldEqual, 1)
+		So(len(polygons[0]), ShouldEqual, 2)
+		So(signedArea(polygons[0][0]), ShouldAlmostEqual, 100)
+		So(signedArea(polygons[0][1]), ShouldAlmostEqual, -10)
+		So(Validate(planarPolygons(polygons, vecToPoint)), ShouldBeEmpty)
+	})
+
+	Convey("Given rectangles touching at a corner, should keep them apart", t, func() {
+		polygons := evenOdd(true, rectRing(0, 0, 10, 10), rectRing(10, 10, 20, 20))
+		So(len(polygons), ShouldEqual, 2)
+		So(polygonsArea(polygons), ShouldAlmostEqual, 200)
+	})
+
+	Convey("Given nested holes in islands, should put every hole in its island", t, func() {
+		rings := [][]vec{rectRing(0, 0, 50, 50), rectRing(5, 5, 45, 45), rectRing(10, 10, 40, 40),
+			rectRing(15, 15, 35, 35), rectRing(20, 20, 30, 30)}
+		polygons := evenOdd(false, rings...)
+		So(len(polygons), ShouldEqual, 3)
+		// smallest outer ring first
+		So(signedArea(polygons[0][0]), ShouldAlmostEqual, 100)
+		So(len(polygons[0]), ShouldEqual, 1)
+		So(signedArea(polygons[1][0]), ShouldAlmostEqual, 900)
+		So(signedArea(polygons[1][1]), ShouldAlmostEqual, -400)
+		So(signedArea(polygons[2][0]), ShouldAlmostEqual, 2500)
+		So(signedArea(polygons[2][1]), ShouldAlmostEqual, -1600)
+		So(polygonsArea(polygons), ShouldAlmostEqual, 2500-1600+900-400+100)
+	})
+
+	Convey("Given a bowtie, should split it into two triangles", t, func() {
+		polygons := evenOdd(false, vecRing(0, 0, 10, 10, 10, 0, 0, 10))
+		So(len(polygons), ShouldEqual, 2)
+		So(polygonsArea(polygons), ShouldAlmostEqual, 50)
+	})
+
+	Convey("Given vertices within tolerance, should not add slivers", t, func() {
+		polygons := evenOdd(true, rectRing(0, 0, 10, 10), rectRing(10+1e-12, 0, 20, 10))
+		So(len(polygons), ShouldEqual, 1)
+		So(len(polygons[0]), ShouldEqual, 1)
+		So(signedArea(polygons[0][0]), ShouldAlmostEqual, 200)
+	})
+
+	Convey("Given nothing inside, should return no polygon", t, func() {
+		So(evenOdd(false, rectRing(0, 0, 10, 10), rectRing(0, 0, 10, 10)), ShouldBeEmpty)
+		So(overlay(nil, func(vec) bool { return true }, 1e-9), ShouldBeEmpty)
+	})
+}
+
+func TestAssemblePolygons(t *testing.T) {
+	Convey("Given a sliver thinner than the tolerance, should drop it", t, func() {
+		g := newTestGraph(1e-6)
+		ring := vecRing(0, 0, 10, 0, 10, 1e-8, 0, 1e-8)
+		boundary := [][2]int{}
+		for k, v := range ring {
+			boundary = append(boundary, [2]int{g.vertex(v), g.vertex(ring[(k+1)%len(ring)])})
+		}
+		So(assemblePolygons(g, boundary), ShouldBeEmpty)
+	})
+}
+
+func TestSplitPinches(t *testing.T) {
+	Convey("Given a cycle passing twice through vertices, should split it into simple rings", t, func() {
+		So(splitPinches([]int{0, 1, 2, 3}), ShouldResemble, [][]int{{0, 1, 2, 3}})
+		So(splitPinches([]int{0, 1, 2, 3, 1, 4, 5}), ShouldResemble, [][]int{{1, 2, 3}, {0, 1, 4, 5}})
+		So(splitPinches([]int{0, 1, 2, 1, 3, 4, 0, 5, 6}), ShouldResemble, [][]int{{1, 2}, {0, 1, 3, 4}, {0, 5, 6}})
+	})
+}
+
+func TestNestHoles(t *testing.T) {
+	Convey("Given holes, should put each in the smallest outer ring around it", t, func() {
+		big, small, other := rectRing(0, 0, 100, 100), rectRing(10, 10, 50, 50), rectRing(60, 60, 90, 90)
+		inSmall, inBig := vecRing(20, 20, 20, 30, 30, 30, 30, 20), vecRing(55, 5, 55, 8, 58, 8, 58, 5)
+		polygons := nestHoles([][]vec{big, other, small}, [][]vec{inSmall, inBig}, 1e-7)
+		So(polygons, ShouldResemble, [][][]vec{{other}, {small, inSmall}, {big, inBig}})
+	})
+
+	Convey("Given a hole outside every outer ring, should drop it", t, func() {
+		outer, outside := rectRing(0, 0, 10, 10), vecRing(20, 20, 20, 30, 30, 30, 30, 20)
+		So(nestHoles([][]vec{outer}, [][]vec{outside}, 1e-7), ShouldResemble, [][][]vec{{outer}})
+		So(nestHoles(nil, [][]vec{outside}, 1e-7), ShouldBeEmpty)
+	})
+}
+
+func TestPlanarTolerance(t *testing.T) {
+	Convey("Given bounds, should scale the tolerance with their size and position", t, func() {
+		So(planarTolerance(rect{0, 0, 10, 10}), ShouldAlmostEqual, 1e-9, 1e-20)
+		So(planarTolerance(rect{1e6, 1e6, 1e6 + 1e-3, 1e6}), ShouldAlmostEqual, 1e-7, 1e-15)
+		So(planarTolerance(rect{0, 0, 0, 0}), ShouldEqual, math.SmallestNonzeroFloat64)
+	})
+}
@@ -0,0 +1,186 @@
+package turfgo
+
+import "math"
+
+// Robust geometric predicates of Jonathan Richard Shewchuk, "Adaptive Precision Floating-Point Arithmetic and
+// Fast Robust Geometric Predicates", 1997, as used by delaunator through the robust-predicates package. The
+// determinants are computed with floating point numbers first, and when the result is smaller than the bound of
+// their rounding error they are computed again exactly with expansions: sums of non overlapping floats sorted
+// by increasing magnitude, with the zero components left out.
+
+const (
+	predicateEpsilon = 1.1102230246251565e-16 // 2^-53
+	predicateSplit   = 134217729              // 2^27 + 1
+
+	orientErrorBound   = (3 + 16*predicateEpsilon) * predicateEpsilon
+	inCircleErrorBound = (10 + 96*predicateEpsilon) * predicateEpsilon
+)
+
+// orient2d returns a positive value if a, b and c are counter-clockwise, a negative value if they are clockwise
+// and zero if they are collinear. Only its sign is exact.
+func orient2d(a, b, c vec) float64 {
+	detLeft := float64((a.x - c.x) * (b.y - c.y))
+	detRight := float64((a.y - c.y) * (b.x - c.x))
+	det := detLeft - detRight
+	var detSum float64
+	switch {
+	case detLeft > 0 && detRight > 0:
+		detSum = detLeft + detRight
+	case detLeft < 0 && detRight < 0:
+		detSum = -detLeft - detRight
+	default:
+		return det
+	}
+	if bound := orientErrorBound * detSum; det >= bound || -det >= bound {
+		return det
+	}
+
+	acx, acy := twoDiff(a.x, c.x), twoDiff(a.y, c.y)
+	bcx, bcy := twoDiff(b.x, c.x), twoDiff(b.y, c.y)
+	return expansionSign(expansionSum(expansionProduct(acx, bcy), negateExpansion(expansionProduct(acy, bcx))))
+}
+
+// inCircle2d returns a positive value if d is inside the circle through the counter-clockwise triangle abc, a
+// negative value if it is outside and zero if it is on the circle. The signs are reversed for a clockwise
+// triangle. Only its sign is exact.
+func inCircle2d(a, b, c, d vec) float64 {
+	adx, ady := a.x-d.x, a.y-d.y
+	bdx, bdy := b.x-d.x, b.y-d.y
+	cdx, cdy := c.x-d.x, c.y-d.y
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+	aLift := float64(adx*adx) + float64(ady*ady)
+	bLift := float64(bdx*bdx) + float64(bdy*bdy)
+	cLift := float64(cdx*cdx) + float64(cdy*cdy)
+	det := float64(aLift*(bdxcdy-cdxbdy)) + float64(bLift*(cdxady-adxcdy)) + float64(cLift*(adxbdy-bdxady))
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*aLift + (math.Abs(cdxady)+math.Abs(adxcdy))*bLift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*cLift
+	if bound := inCircleErrorBound * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	ax, ay := twoDiff(a.x, d.x), twoDiff(a.y, d.y)
+	bx, by := twoDiff(b.x, d.x), twoDiff(b.y, d.y)
+	cx, cy := twoDiff(c.x, d.x), twoDiff(c.y, d.y)
+	lift := func(x, y []float64) []float64 {
+		return expansionSum(expansionProduct(x, x), expansionProduct(y, y))
+	}
+	crossProduct := func(x1, y1, x2, y2 []float64) []float64 {
+		return expansionSum(expansionProduct(x1, y2), negateExpansion(expansionProduct(y1, x2)))
+	}
+	return expansionSign(expansionSum(expansionSum(
+		expansionProduct(lift(ax, ay), crossProduct(bx, by, cx, cy)),
+		expansionProduct(lift(bx, by), crossProduct(cx, cy, ax, ay))),
+		expansionProduct(lift(cx, cy), crossProduct(ax, ay, bx, by))))
+}
+
+// twoSum returns a + b as the rounded sum and its rounding error
+func twoSum(a, b float64) (float64, float64) {
+	x := a + b
+	bVirtual := x - a
+	aVirtual := x - bVirtual
+	return x, (a - aVirtual) + (b - bVirtual)
+}
+
+// fastTwoSum is twoSum for |a| >= |b|
+func fastTwoSum(a, b float64) (float64, float64) {
+	x := a + b
+	return x, b - (x - a)
+}
+
+// twoDiff returns the exact difference a - b as an expansion
+func twoDiff(a, b float64) []float64 {
+	x := a - b
+	bVirtual := a - x
+	aVirtual := x + bVirtual
+	return []float64{(a - aVirtual) + (bVirtual - b), x}
+}
+
+// splitFloat returns the high and low halves of the significand of a. The products are converted explicitly so
+// that they are rounded and never fused into a multiply-add, which would break the exact arithmetic.
+func splitFloat(a float64) (float64, float64) {
+	c := float64(predicateSplit * a)
+	high := c - (c - a)
+	return high, a - high
+}
+
+// twoProduct returns a * b as the rounded product and its rounding error
+func twoProduct(a, b float64) (float64, float64) {
+	x := float64(a * b)
+	aHigh, aLow := splitFloat(a)
+	bHigh, bLow := splitFloat(b)
+	err := x - float64(aHigh*bHigh) - float64(aLow*bHigh) - float64(aHigh*bLow)
+	return x, float64(aLow*bLow) - err
+}
+
+// growExpansion returns the expansion e + b
+func growExpansion(e []float64, b float64) []float64 {
+	h := []float64{}
+	q := b
+	for _, component := range e {
+		var tail float64
+		q, tail = twoSum(q, component)
+		if tail != 0 {
+			h = append(h, tail)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// expansionSum returns the expansion e + f
+func expansionSum(e, f []float64) []float64 {
+	for _, component := range f {
+		e = growExpansion(e, component)
+	}
+	return e
+}
+
+// scaleExpansion returns the expansion e * b
+func scaleExpansion(e []float64, b float64) []float64 {
+	h := []float64{}
+	q, tail := twoProduct(e[0], b)
+	if tail != 0 {
+		h = append(h, tail)
+	}
+	for _, component := range e[1:] {
+		product, productTail := twoProduct(component, b)
+		sum, tail := twoSum(q, productTail)
+		if tail != 0 {
+			h = append(h, tail)
+		}
+		q, tail = fastTwoSum(product, sum)
+		if tail != 0 {
+			h = append(h, tail)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// expansionProduct returns the expansion e * f
+func expansionProduct(e, f []float64) []float64 {
+	product := []float64{0}
+	for _, component := range f {
+		product = expansionSum(product, scaleExpansion(e, component))
+	}
+	return product
+}
+
+func negateExpansion(e []float64) []float64 {
+	negated := make([]float64, len(e))
+	for i, component := range e {
+		negated[i] = -component
+	}
+	return negated
+}
+
+// expansionSign returns the largest component of an expansion, which has its sign
+func expansionSign(e []float64) float64 {
+	return e[len(e)-1]
+}
@@ -0,0 +1,108 @@
+package turfgo
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func ratDiff(a, b float64) *big.Rat {
+	return new(big.Rat).Sub(new(big.Rat).SetFloat64(a), new(big.Rat).SetFloat64(b))
+}
+
+func ratCross(ax, ay, bx, by *big.Rat) *big.Rat {
+	return new(big.Rat).Sub(new(big.Rat).Mul(ax, by), new(big.Rat).Mul(ay, bx))
+}
+
+// exactOrient and exactInCircle compute the determinants with rational numbers
+func exactOrient(a, b, c vec) int {
+	return ratCross(ratDiff(a.x, c.x), ratDiff(a.y, c.y), ratDiff(b.x, c.x), ratDiff(b.y, c.y)).Sign()
+}
+
+func exactInCircle(a, b, c, d vec) int {
+	ax, ay := ratDiff(a.x, d.x), ratDiff(a.y, d.y)
+	bx, by := ratDiff(b.x, d.x), ratDiff(b.y, d.y)
+	cx, cy := ratDiff(c.x, d.x), ratDiff(c.y, d.y)
+	lift := func(x, y *big.Rat) *big.Rat {
+		return new(big.Rat).Add(new(big.Rat).Mul(x, x), new(big.Rat).Mul(y, y))
+	}
+	det := new(big.Rat).Mul(lift(ax, ay), ratCross(bx, by, cx, cy))
+	det.Add(det, new(big.Rat).Mul(lift(bx, by), ratCross(cx, cy, ax, ay)))
+	det.Add(det, new(big.Rat).Mul(lift(cx, cy), ratCross(ax, ay, bx, by)))
+	return det.Sign()
+}
+
+func sign(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
+
+func TestOrient2d(t *testing.T) {
+	Convey("Given clear cases, should return the orientation", t, func() {
+		So(orient2d(vec{0, 0}, vec{1, 0}, vec{0, 1}), ShouldBeGreaterThan, 0)
+		So(orient2d(vec{0, 0}, vec{0, 1}, vec{1, 0}), ShouldBeLessThan, 0)
+		So(orient2d(vec{0, 0}, vec{1, 1}, vec{2, 2}), ShouldEqual, 0)
+	})
+
+	Convey("Given points next to a line, should match the exact orientation", t, func() {
+		// the points of Kettner et al., "Classroom examples of robustness problems in geometric computations"
+		q, r := vec{12, 12}, vec{24, 24}
+		ulp := math.Pow(2, -53)
+		for i := 0; i < 64; i++ {
+			for j := 0; j < 64; j++ {
+				p := vec{0.5 + float64(i)*ulp, 0.5 + float64(j)*ulp}
+				So(sign(orient2d(p, q, r)), ShouldEqual, exactOrient(p, q, r))
+			}
+		}
+	})
+
+	Convey("Given nearly collinear coordinates, should match the exact orientation", t, func() {
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			a := vec{random.Float64() * 360, random.Float64() * 180}
+			b := vec{random.Float64() * 360, random.Float64() * 180}
+			f := random.Float64()
+			c := vec{a.x + f*(b.x-a.x), a.y + f*(b.y-a.y)}
+			So(sign(orient2d(a, b, c)), ShouldEqual, exactOrient(a, b, c))
+		}
+	})
+}
+
+func TestInCircle2d(t *testing.T) {
+	Convey("Given clear cases, should tell if the point is in the circle", t, func() {
+		a, b, c := vec{0, 0}, vec{1, 0}, vec{0, 1}
+		So(inCircle2d(a, b, c, vec{0.5, 0.5}), ShouldBeGreaterThan, 0)
+		So(inCircle2d(a, b, c, vec{2, 2}), ShouldBeLessThan, 0)
+		So(inCircle2d(a, b, c, vec{1, 1}), ShouldEqual, 0)
+		So(inCircle2d(a, c, b, vec{0.5, 0.5}), ShouldBeLessThan, 0)
+	})
+
+	Convey("Given nearly cocircular grid points, should match the exact result", t, func() {
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			// a cell of a fine grid of coordinates, moved by a few ulps
+			x, y, step := -180+random.Float64()*360, -90+random.Float64()*180, 1e-6*random.Float64()
+			jitter := func(v float64) float64 {
+				return v + float64(random.Intn(5)-2)*math.Abs(v)*math.Pow(2, -52)
+			}
+			a, b := vec{jitter(x), jitter(y)}, vec{jitter(x + step), jitter(y)}
+			c, d := vec{jitter(x + step), jitter(y + step)}, vec{jitter(x), jitter(y + step)}
+			So(sign(inCircle2d(a, b, c, d)), ShouldEqual, exactInCircle(a, b, c, d))
+		}
+	})
+}
+
+func BenchmarkInCircle2d(b *testing.B) {
+	a, c, d := vec{-77.03, 38.89}, vec{-77.02, 38.90}, vec{-77.03, 38.90}
+	for n := 0; n < b.N; n++ {
+		testResultF = inCircle2d(a, vec{-77.02, 38.89}, c, d)
+	}
+}
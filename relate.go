@@ -0,0 +1,283 @@
+package turfgo
+
+import "math"
+
+// Locations of a point relative to a geometry, the rows and columns of an IntersectionMatrix
+const (
+	relateInterior = iota
+	relateBoundary
+	relateExterior
+)
+
+// relateGeometry is a geometry split into its areas, lines and points with planar coordinates, to locate points
+// relative to it
+type relateGeometry struct {
+	dimension int
+	rings     [][]vec
+	owners    []int
+	lines     [][2]vec
+	ends      map[vec]int
+	points    []vec
+
+	tolerance float64
+	areas     *ringIndex
+	ringEdges [][2]vec
+	ringTree  *RTree
+	lineTree  *RTree
+	boundary  []vec
+	boundTree *RTree
+	pointTree *RTree
+}
+
+func newRelateGeometry(geometry Geometry) *relateGeometry {
+	r := &relateGeometry{dimension: -1, ends: map[vec]int{}}
+	r.add(geometry)
+	return r
+}
+
+func (r *relateGeometry) add(geometry Geometry) {
+	switch g := geometry.(type) {
+	case nil:
+	case *Point:
+		r.addPoints([]*Point{g})
+	case *MultiPoint:
+		r.addPoints(g.Points)
+	case *LineString:
+		r.addLine(g)
+	case *MultiLineString:
+		for _, line := range g.LineStrings {
+			r.addLine(line)
+		}
+	case *Polygon, *MultiPolygon:
+		r.addPolygons(g.(PolygonI))
+	case *GeometryCollection:
+		for _, geometry := range g.Geometries {
+			r.add(geometry)
+		}
+	case *Feature:
+		if g != nil {
+			r.add(g.Geometry)
+		}
+	case *FeatureCollection:
+		for _, feature := range g.Features {
+			r.add(feature)
+		}
+	case PolygonI:
+		r.addPolygons(g)
+	default:
+		r.addPoints(g.GetPoints())
+	}
+}
+
+func (r *relateGeometry) addPoints(points []*Point) {
+	for _, point := range points {
+		if point != nil && !isEmptyPoint(point) {
+			r.points = append(r.points, pointToVec(point))
+			r.dimension = maxInt(r.dimension, 0)
+		}
+	}
+}
+
+// addLine adds the segments of a line, its end points are counted for the mod 2 rule: the boundary of lines is
+// made of the end points shared by an odd number of lines
+func (r *relateGeometry) addLine(line *LineString) {
+	if line == nil {
+		return
+	}
+	vertices := []vec{}
+	for _, point := range line.Points {
+		v := pointToVec(point)
+		if !isEmptyPoint(point) && (len(vertices) == 0 || vertices[len(vertices)-1] != v) {
+			vertices = append(vertices, v)
+		}
+	}
+	if len(vertices) == 1 {
+		r.points = append(r.points, vertices[0])
+		r.dimension = maxInt(r.dimension, 0)
+	}
+	if len(vertices) < 2 {
+		return
+	}
+	for i := 1; i < len(vertices); i++ {
+		r.lines = append(r.lines, [2]vec{vertices[i-1], vertices[i]})
+	}
+	r.ends[vertices[0]]++
+	r.ends[vertices[len(vertices)-1]]++
+	r.dimension = maxInt(r.dimension, 1)
+}
+
+func (r *relateGeometry) addPolygons(polygon PolygonI) {
+	rings, owners := planarRings(polygon)
+	offset := 0
+	if len(r.owners) > 0 {
+		offset = r.owners[len(r.owners)-1] + 1
+	}
+	for i, ring := range rings {
+		r.rings = append(r.rings, ring)
+		r.owners = append(r.owners, owners[i]+offset)
+		r.dimension = 2
+	}
+}
+
+// bounds returns the rectangle around all the vertices of the geometry
+func (r *relateGeometry) bounds() rect {
+	bounds := ringRect(r.points)
+	for _, ring := range r.rings {
+		bounds = bounds.extend(ringRect(ring))
+	}
+	for _, line := range r.lines {
+		bounds = bounds.extend(segmentRect(line[0], line[1]))
+	}
+	return bounds
+}
+
+// index builds the indexes used by locate, points closer than tolerance are considered equal
+func (r *relateGeometry) index(tolerance float64) {
+	r.tolerance = tolerance
+	r.areas = newRingIndex(r.rings, r.owners)
+	r.ringEdges = [][2]vec{}
+	for _, ring := range r.rings {
+		for k, v := range ring {
+			r.ringEdges = append(r.ringEdges, [2]vec{v, ring[(k+1)%len(ring)]})
+		}
+	}
+	r.ringTree = newSegmentTree(r.ringEdges, tolerance)
+	r.lineTree = newSegmentTree(r.lines, tolerance)
+	r.boundary = []vec{}
+	for v, count := range r.ends {
+		if count%2 == 1 {
+			r.boundary = append(r.boundary, v)
+		}
+	}
+	r.boundTree = newVertexTree(r.boundary, tolerance)
+	r.pointTree = newVertexTree(r.points, tolerance)
+}
+
+// locate tells if a point is in the interior, on the boundary or in the exterior of the geometry. The parts of
+// a collection are merged: the interior of any part wins over the boundary of another.
+func (r *relateGeometry) locate(p vec) int {
+	location := relateExterior
+	if nearSegment(r.ringTree, r.ringEdges, p, r.tolerance) {
+		location = relateBoundary
+	} else if r.areas.contains(p) {
+		return relateInterior
+	}
+	if nearSegment(r.lineTree, r.lines, p, r.tolerance) {
+		if !nearVertex(r.boundTree, r.boundary, p, r.tolerance) {
+			return relateInterior
+		}
+		location = relateBoundary
+	}
+	if nearVertex(r.pointTree, r.points, p, r.tolerance) {
+		return relateInterior
+	}
+	return location
+}
+
+func newSegmentTree(segments [][2]vec, tolerance float64) *RTree {
+	rects := make([]rect, len(segments))
+	for i, segment := range segments {
+		r := segmentRect(segment[0], segment[1])
+		rects[i] = rect{r.minX - tolerance, r.minY - tolerance, r.maxX + tolerance, r.maxY + tolerance}
+	}
+	return newRectIndex(rects)
+}
+
+func newVertexTree(vertices []vec, tolerance float64) *RTree {
+	rects := make([]rect, len(vertices))
+	for i, v := range vertices {
+		rects[i] = rect{v.x - tolerance, v.y - tolerance, v.x + tolerance, v.y + tolerance}
+	}
+	return newRectIndex(rects)
+}
+
+func nearSegment(tree *RTree, segments [][2]vec, p vec, tolerance float64) bool {
+	found := false
+	tree.search(rect{p.x, p.y, p.x, p.y}, func(i int) bool {
+		_, found = onSegment(p, segments[i][0], segments[i][1], tolerance)
+		return !found
+	})
+	return found
+}
+
+func nearVertex(tree *RTree, vertices []vec, p vec, tolerance float64) bool {
+	found := false
+	tree.search(rect{p.x, p.y, p.x, p.y}, func(i int) bool {
+		found = vertices[i].sub(p).length() <= tolerance
+		return !found
+	})
+	return found
+}
+
+// relate computes the intersection matrix of two geometries. The segments of both are split where they meet
+// into a planar graph, then a point of every vertex, edge and face of the graph is located relative to both
+// geometries. All the points of an edge or a face share the same locations, so each of them adds its
+// dimension to the matrix entry of its locations.
+func relate(a, b *relateGeometry) IntersectionMatrix {
+	m := IntersectionMatrix{{-1, -1, -1}, {-1, -1, -1}, {-1, -1, 2}}
+	bounds := a.bounds().extend(b.bounds())
+	if bounds.minX > bounds.maxX {
+		return m
+	}
+	tolerance := planarTolerance(bounds)
+	a.index(tolerance)
+	b.index(tolerance)
+	add := func(p vec, dimension int) {
+		i, j := a.locate(p), b.locate(p)
+		m[i][j] = maxInt(m[i][j], dimension)
+	}
+
+	// points are edges of no length, which split the edges they lie on
+	edges := [][2]vec{}
+	for _, r := range []*relateGeometry{a, b} {
+		edges = append(append(edges, r.ringEdges...), r.lines...)
+		for _, v := range r.points {
+			edges = append(edges, [2]vec{v, v})
+		}
+	}
+	g := &overlayGraph{tolerance: tolerance, ids: map[[2]int64]int{}}
+	pieces := splitEdges(g, edges)
+	for _, v := range g.vertices {
+		add(v, 0)
+	}
+	for _, piece := range pieces {
+		add(g.vertices[piece[0]].add(g.vertices[piece[1]]).scale(0.5), 1)
+	}
+	if a.dimension < 2 && b.dimension < 2 {
+		// without areas every face is in the exterior of both geometries
+		return m
+	}
+
+	halfEdges := make([][2]int, 2*len(pieces))
+	for i, piece := range pieces {
+		halfEdges[2*i], halfEdges[2*i+1] = piece, [2]int{piece[1], piece[0]}
+	}
+	for _, face := range traceCycles(g, halfEdges) {
+		ring := make([]vec, len(face))
+		for i, h := range face {
+			ring[i] = g.vertices[halfEdges[h][0]]
+		}
+		// slivers and the two sides of lines have no room for a point away from their edges
+		if area := signedArea(ring); area >= 0 && area <= tolerance*ringLength(ring) {
+			continue
+		}
+		add(facePoint(g, halfEdges, face, 100*tolerance), 2)
+	}
+	return m
+}
+
+// planarTolerance returns the distance under which planar algorithms merge points, relative to the size and
+// the position of bounds
+func planarTolerance(bounds rect) float64 {
+	size := math.Max(bounds.maxX-bounds.minX, bounds.maxY-bounds.minY)
+	magnitude := math.Max(math.Max(math.Abs(bounds.minX), math.Abs(bounds.maxX)),
+		math.Max(math.Abs(bounds.minY), math.Abs(bounds.maxY)))
+	return math.Max(math.Max(size*1e-10, magnitude*1e-13), math.SmallestNonzeroFloat64)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,646 @@
+package turfgo
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+const defaultRTreeMaxEntries = 9
+
+// RTree is a spatial index of geometries keyed on their bounding boxes, ported from RBush
+// (https://github.com/mourner/rbush). It can be bulk loaded, which gives a faster tree than inserting
+// geometries one by one. Geometries without points are not indexed.
+type RTree struct {
+	maxEntries int
+	minEntries int
+	root       *rtreeNode
+}
+
+// rtreeNode is a node of the tree, the children of leaves are the geometries, held in nodes of height 0. The
+// items of a tree built by newRectIndex have no geometry and are known by their index.
+type rtreeNode struct {
+	bbox     BoundingBox
+	children []*rtreeNode
+	geometry Geometry
+	index    int
+	height   int
+	leaf     bool
+}
+
+// NewRTree creates an empty tree. maxEntries is the maximum number of children of a node, 9 is used when it
+// is not positive. Higher values give faster insertion and slower search.
+func NewRTree(maxEntries int) *RTree {
+	if maxEntries <= 0 {
+		maxEntries = defaultRTreeMaxEntries
+	}
+	maxEntries = int(math.Max(4, float64(maxEntries)))
+	t := &RTree{maxEntries: maxEntries, minEntries: int(math.Max(2, math.Ceil(float64(maxEntries)*0.4)))}
+	t.Clear()
+	return t
+}
+
+// Clear removes all geometries from the tree
+func (t *RTree) Clear() {
+	t.root = newRTreeNode(nil)
+}
+
+// All returns all the geometries of the tree
+func (t *RTree) All() []Geometry {
+	return t.root.all(nil)
+}
+
+// Search returns the geometries whose bounding box intersects bbox
+func (t *RTree) Search(bbox *BoundingBox) []Geometry {
+	result := []Geometry{}
+	if bbox == nil || !intersectsBBox(bbox, &t.root.bbox) {
+		return result
+	}
+	toSearch := []*rtreeNode{t.root}
+	for len(toSearch) > 0 {
+		node := toSearch[len(toSearch)-1]
+		toSearch = toSearch[:len(toSearch)-1]
+		for _, child := range node.children {
+			if !intersectsBBox(bbox, &child.bbox) {
+				continue
+			}
+			if node.leaf {
+				result = append(result, child.geometry)
+			} else if containsBBox(bbox, &child.bbox) {
+				result = child.all(result)
+			} else {
+				toSearch = append(toSearch, child)
+			}
+		}
+	}
+	return result
+}
+
+// Collides tells if any geometry of the tree has a bounding box intersecting bbox
+func (t *RTree) Collides(bbox *BoundingBox) bool {
+	if bbox == nil || !intersectsBBox(bbox, &t.root.bbox) {
+		return false
+	}
+	toSearch := []*rtreeNode{t.root}
+	for len(toSearch) > 0 {
+		node := toSearch[len(toSearch)-1]
+		toSearch = toSearch[:len(toSearch)-1]
+		for _, child := range node.children {
+			if intersectsBBox(bbox, &child.bbox) {
+				if node.leaf || containsBBox(bbox, &child.bbox) {
+					return true
+				}
+				toSearch = append(toSearch, child)
+			}
+		}
+	}
+	return false
+}
+
+// Insert adds a geometry to the tree
+func (t *RTree) Insert(geometry Geometry) {
+	if item := newRTreeItem(geometry); item != nil {
+		t.insert(item, t.root.height-1)
+	}
+}
+
+// Load bulk loads geometries with the Overlap Minimizing Top-down algorithm. Loading into a tree which is
+// not empty builds a tree of the new geometries and merges it in.
+func (t *RTree) Load(geometries []Geometry) {
+	items := []*rtreeNode{}
+	for _, geometry := range geometries {
+		if item := newRTreeItem(geometry); item != nil {
+			items = append(items, item)
+		}
+	}
+	t.load(items)
+}
+
+func (t *RTree) load(items []*rtreeNode) {
+	if len(items) < t.minEntries {
+		for _, item := range items {
+			t.insert(item, t.root.height-1)
+		}
+		return
+	}
+
+	node := t.build(items, 0)
+	if len(t.root.children) == 0 {
+		t.root = node
+	} else if t.root.height == node.height {
+		t.splitRoot(t.root, node)
+	} else {
+		if t.root.height < node.height {
+			t.root, node = node, t.root
+		}
+		// insert the smaller tree in the larger one at the right level
+		t.insert(node, t.root.height-node.height-1)
+	}
+}
+
+// Remove removes a geometry from the tree, geometries are compared with ==. It returns false if the geometry
+// is not in the tree.
+func (t *RTree) Remove(geometry Geometry) bool {
+	item := newRTreeItem(geometry)
+	if item == nil {
+		return false
+	}
+	return t.remove(item, []*rtreeNode{t.root})
+}
+
+func (t *RTree) remove(item *rtreeNode, path []*rtreeNode) bool {
+	node := path[len(path)-1]
+	if !containsBBox(&node.bbox, &item.bbox) {
+		return false
+	}
+	for i, child := range node.children {
+		if node.leaf {
+			if child.geometry == item.geometry {
+				node.children = append(node.children[:i], node.children[i+1:]...)
+				t.condense(path)
+				return true
+			}
+		} else if t.remove(item, append(path, child)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Nearest returns up to k geometries of the tree closest to point, the closest first, within maxDistance in
+// the given unit. All the geometries within maxDistance are returned when k is not positive, pass
+// math.Inf(1) as maxDistance for no limit. Points are ranked by their Distance to point, other geometries by
+// the distance to their bounding box.
+func (t *RTree) Nearest(point *Point, k int, maxDistance float64, unit Unit) []Geometry {
+	neighbours := []Geometry{}
+	t.nearest(point, maxDistance, unit, func(geometry Geometry, distance float64) bool {
+		neighbours = append(neighbours, geometry)
+		return k <= 0 || len(neighbours) < k
+	})
+	return neighbours
+}
+
+// nearest calls found with the geometries of the tree by increasing distance to point, until found returns
+// false. Nodes are visited in the order of the great circle distance from point to their bounding box, a lower
+// bound of the distance to the geometries they hold.
+func (t *RTree) nearest(point *Point, maxDistance float64, unit Unit, found func(Geometry, float64) bool) {
+	queue := &rtreeQueue{}
+	node := t.root
+	for node != nil {
+		for _, child := range node.children {
+			distance := bboxDistance(point, &child.bbox, unit)
+			switch p := child.geometry.(type) {
+			case *Point:
+				distance = Distance(point, p, unit)
+			case *indexedPoint:
+				distance = Distance(point, p.Point, unit)
+			}
+			if distance <= maxDistance {
+				heap.Push(queue, rtreeQueueItem{child, distance})
+			}
+		}
+		for queue.Len() > 0 && (*queue)[0].node.geometry != nil {
+			item := heap.Pop(queue).(rtreeQueueItem)
+			if !found(item.node.geometry, item.distance) {
+				return
+			}
+		}
+		node = nil
+		if queue.Len() > 0 {
+			node = heap.Pop(queue).(rtreeQueueItem).node
+		}
+	}
+}
+
+func newRTreeNode(children []*rtreeNode) *rtreeNode {
+	node := &rtreeNode{children: children, height: 1, leaf: true}
+	node.calcBBox()
+	return node
+}
+
+func newRTreeItem(geometry Geometry) *rtreeNode {
+	if geometry == nil {
+		return nil
+	}
+	bounds := geometry.Bounds()
+	if bounds == nil || !(bounds.West <= bounds.East && bounds.South <= bounds.North) {
+		return nil
+	}
+	return &rtreeNode{bbox: *bounds, geometry: geometry}
+}
+
+func (node *rtreeNode) all(result []Geometry) []Geometry {
+	toSearch := []*rtreeNode{node}
+	for len(toSearch) > 0 {
+		node := toSearch[len(toSearch)-1]
+		toSearch = toSearch[:len(toSearch)-1]
+		if node.leaf {
+			for _, child := range node.children {
+				result = append(result, child.geometry)
+			}
+		} else {
+			toSearch = append(toSearch, node.children...)
+		}
+	}
+	return result
+}
+
+func (node *rtreeNode) calcBBox() {
+	node.bbox = *NewInfiniteBBox()
+	for _, child := range node.children {
+		extendBBox(&node.bbox, &child.bbox)
+	}
+}
+
+func (t *RTree) build(items []*rtreeNode, height int) *rtreeNode {
+	n := len(items)
+	m := t.maxEntries
+	if n <= m {
+		// leaf level
+		return newRTreeNode(append([]*rtreeNode{}, items...))
+	}
+	if height == 0 {
+		// target height of the tree and number of entries of the root which maximize storage utilization
+		height = int(math.Ceil(math.Log(float64(n)) / math.Log(float64(m))))
+		m = int(math.Ceil(float64(n) / math.Pow(float64(m), float64(height-1))))
+	}
+	node := &rtreeNode{height: height}
+
+	// split the items into m mostly square tiles
+	n2 := int(math.Ceil(float64(n) / float64(m)))
+	n1 := n2 * int(math.Ceil(math.Sqrt(float64(m))))
+	sortNodes(items, compareMinX)
+	for i := 0; i < n; i += n1 {
+		slice := items[i:minInt(i+n1, n)]
+		sortNodes(slice, compareMinY)
+		for j := 0; j < len(slice); j += n2 {
+			node.children = append(node.children, t.build(slice[j:minInt(j+n2, len(slice))], height-1))
+		}
+	}
+	node.calcBBox()
+	return node
+}
+
+// insert puts an item, or a node, at the given level and splits the nodes which overflow
+func (t *RTree) insert(item *rtreeNode, level int) {
+	path := []*rtreeNode{}
+	node := t.chooseSubtree(&item.bbox, t.root, level, &path)
+	node.children = append(node.children, item)
+	extendBBox(&node.bbox, &item.bbox)
+
+	for level >= 0 && len(path[level].children) > t.maxEntries {
+		t.split(path, level)
+		level--
+	}
+	for i := level; i >= 0; i-- {
+		extendBBox(&path[i].bbox, &item.bbox)
+	}
+}
+
+// chooseSubtree finds the node needing the least enlargement to hold bbox, it saves the nodes on the way
+func (t *RTree) chooseSubtree(bbox *BoundingBox, node *rtreeNode, level int, path *[]*rtreeNode) *rtreeNode {
+	for {
+		*path = append(*path, node)
+		if node.leaf || len(*path)-1 == level {
+			return node
+		}
+		minArea, minEnlargement := math.Inf(1), math.Inf(1)
+		target := node.children[0]
+		for _, child := range node.children {
+			area := bboxArea(&child.bbox)
+			enlargement := enlargedArea(bbox, &child.bbox) - area
+			if enlargement < minEnlargement {
+				minEnlargement = enlargement
+				minArea = math.Min(area, minArea)
+				target = child
+			} else if enlargement == minEnlargement && area < minArea {
+				minArea = area
+				target = child
+			}
+		}
+		node = target
+	}
+}
+
+// split splits an overflowed node into two
+func (t *RTree) split(path []*rtreeNode, level int) {
+	node := path[level]
+	total := len(node.children)
+	t.chooseSplitAxis(node, t.minEntries, total)
+	index := t.chooseSplitIndex(node, t.minEntries, total)
+
+	newNode := newRTreeNode(append([]*rtreeNode{}, node.children[index:]...))
+	newNode.height, newNode.leaf = node.height, node.leaf
+	node.children = node.children[:index]
+	node.calcBBox()
+	newNode.calcBBox()
+	if level > 0 {
+		path[level-1].children = append(path[level-1].children, newNode)
+	} else {
+		t.splitRoot(node, newNode)
+	}
+}
+
+func (t *RTree) splitRoot(node, newNode *rtreeNode) {
+	t.root = newRTreeNode([]*rtreeNode{node, newNode})
+	t.root.height = node.height + 1
+	t.root.leaf = false
+}
+
+// chooseSplitIndex finds the split with the least overlap, then the least area
+func (t *RTree) chooseSplitIndex(node *rtreeNode, m, total int) int {
+	index := -1
+	minOverlap, minArea := math.Inf(1), math.Inf(1)
+	for i := m; i <= total-m; i++ {
+		bbox1, bbox2 := distBBox(node, 0, i), distBBox(node, i, total)
+		overlap := intersectionArea(&bbox1, &bbox2)
+		area := bboxArea(&bbox1) + bboxArea(&bbox2)
+		if overlap < minOverlap {
+			minOverlap = overlap
+			index = i
+			minArea = math.Min(area, minArea)
+		} else if overlap == minOverlap && area < minArea {
+			minArea = area
+			index = i
+		}
+	}
+	if index == -1 {
+		return total - m
+	}
+	return index
+}
+
+// chooseSplitAxis sorts the children along the axis giving the smallest margins
+func (t *RTree) chooseSplitAxis(node *rtreeNode, m, total int) {
+	xMargin := t.allDistMargin(node, m, total, compareMinX)
+	yMargin := t.allDistMargin(node, m, total, compareMinY)
+	if xMargin < yMargin {
+		sortNodes(node.children, compareMinX)
+	}
+}
+
+// allDistMargin returns the total margin of all the splits where each node is at least m full
+func (t *RTree) allDistMargin(node *rtreeNode, m, total int, compare func(a, b *rtreeNode) bool) float64 {
+	sortNodes(node.children, compare)
+	left, right := distBBox(node, 0, m), distBBox(node, total-m, total)
+	margin := bboxMargin(&left) + bboxMargin(&right)
+	for i := m; i < total-m; i++ {
+		extendBBox(&left, &node.children[i].bbox)
+		margin += bboxMargin(&left)
+	}
+	for i := total - m - 1; i >= m; i-- {
+		extendBBox(&right, &node.children[i].bbox)
+		margin += bboxMargin(&right)
+	}
+	return margin
+}
+
+// condense removes the empty nodes of a path and updates the bounding boxes
+func (t *RTree) condense(path []*rtreeNode) {
+	for i := len(path) - 1; i >= 0; i-- {
+		if len(path[i].children) > 0 {
+			path[i].calcBBox()
+			continue
+		}
+		if i == 0 {
+			t.Clear()
+			continue
+		}
+		siblings := path[i-1].children
+		for k, sibling := range siblings {
+			if sibling == path[i] {
+				path[i-1].children = append(siblings[:k], siblings[k+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func distBBox(node *rtreeNode, from, to int) BoundingBox {
+	bbox := *NewInfiniteBBox()
+	for _, child := range node.children[from:to] {
+		extendBBox(&bbox, &child.bbox)
+	}
+	return bbox
+}
+
+func sortNodes(nodes []*rtreeNode, less func(a, b *rtreeNode) bool) {
+	sort.Slice(nodes, func(i, j int) bool { return less(nodes[i], nodes[j]) })
+}
+
+func compareMinX(a, b *rtreeNode) bool {
+	return a.bbox.West < b.bbox.West
+}
+
+func compareMinY(a, b *rtreeNode) bool {
+	return a.bbox.South < b.bbox.South
+}
+
+func extendBBox(bbox *BoundingBox, other *BoundingBox) {
+	bbox.West = math.Min(bbox.West, other.West)
+	bbox.South = math.Min(bbox.South, other.South)
+	bbox.East = math.Max(bbox.East, other.East)
+	bbox.North = math.Max(bbox.North, other.North)
+}
+
+func intersectsBBox(a, b *BoundingBox) bool {
+	return b.West <= a.East && b.South <= a.North && b.East >= a.West && b.North >= a.South
+}
+
+func containsBBox(a, b *BoundingBox) bool {
+	return a.West <= b.West && a.South <= b.South && b.East <= a.East && b.North <= a.North
+}
+
+func bboxArea(bbox *BoundingBox) float64 {
+	return (bbox.East - bbox.West) * (bbox.North - bbox.South)
+}
+
+func bboxMargin(bbox *BoundingBox) float64 {
+	return (bbox.East - bbox.West) + (bbox.North - bbox.South)
+}
+
+func enlargedArea(a, b *BoundingBox) float64 {
+	return (math.Max(b.East, a.East) - math.Min(b.West, a.West)) * (math.Max(b.North, a.North) - math.Min(b.South, a.South))
+}
+
+func intersectionArea(a, b *BoundingBox) float64 {
+	west, south := math.Max(a.West, b.West), math.Max(a.South, b.South)
+	east, north := math.Min(a.East, b.East), math.Min(a.North, b.North)
+	return math.Max(0, east-west) * math.Max(0, north-south)
+}
+
+// bboxDistance returns a lower bound of the distance from a point to the geometries in a bounding box: the
+// great circle distance to the closest point of the box on the sphere, or on the WGS 84 ellipsoid the length of
+// the shortest path on its inscribed sphere of radius b. Every point of the ellipsoid is outside this sphere
+// and the projection to the closest point of a ball never lengthens a path, so a geodesic between two points
+// is at least as long as the great circle between their projections. The projection keeps the longitude and
+// turns the geodetic latitude into the geocentric latitude, which is monotonic, so the box is projected to a
+// box.
+func bboxDistance(point *Point, bbox *BoundingBox, unit Unit) float64 {
+	if currentEarthModel() == WGS84 {
+		projected := &BoundingBox{bbox.West, geocentricLat(bbox.South), bbox.East, geocentricLat(bbox.North)}
+		angle := bboxAngle(&Point{geocentricLat(point.Lat), point.Lng}, projected)
+		return fromMeters(angle*wgs84B, unit)
+	}
+	return RadsToDistance(bboxAngle(point, bbox), unit)
+}
+
+// bboxAngle returns the angle from a point to the closest point of a bounding box on a sphere, in radians.
+// Reference: https://github.com/mourner/geokdbush
+func bboxAngle(point *Point, bbox *BoundingBox) float64 {
+	lat, lng := point.Lat, point.Lng
+	var h float64
+	if lng >= bbox.West && lng <= bbox.East {
+		// the point is between the meridians of the box
+		if lat < bbox.South {
+			h = haverSin(DegreeToRads(lat - bbox.South))
+		} else if lat > bbox.North {
+			h = haverSin(DegreeToRads(lat - bbox.North))
+		}
+	} else {
+		// the closest point is on the closest meridian of the box, at the latitude where the great circle
+		// through the point meets the meridian at a right angle, or at a corner
+		cosLat := math.Cos(DegreeToRads(lat))
+		haverSinDLng := math.Min(haverSin(DegreeToRads(bbox.West-lng)), haverSin(DegreeToRads(bbox.East-lng)))
+		extremumLat := vertexLat(lat, haverSinDLng)
+		if extremumLat > bbox.South && extremumLat < bbox.North {
+			h = haverSinDistance(haverSinDLng, cosLat, lat, extremumLat)
+		} else {
+			h = math.Min(haverSinDistance(haverSinDLng, cosLat, lat, bbox.South),
+				haverSinDistance(haverSinDLng, cosLat, lat, bbox.North))
+		}
+	}
+	return 2 * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// geocentricLat converts a geodetic latitude on the WGS 84 ellipsoid to the latitude of the point seen from the
+// centre of the ellipsoid, in degrees
+func geocentricLat(lat float64) float64 {
+	if math.Abs(lat) >= 90 {
+		return lat
+	}
+	return RadsToDegree(math.Atan((1 - wgs84F) * (1 - wgs84F) * math.Tan(DegreeToRads(lat))))
+}
+
+func haverSin(theta float64) float64 {
+	s := math.Sin(theta / 2)
+	return s * s
+}
+
+func haverSinDistance(haverSinDLng, cosLat1, lat1, lat2 float64) float64 {
+	return cosLat1*math.Cos(DegreeToRads(lat2))*haverSinDLng + haverSin(DegreeToRads(lat1-lat2))
+}
+
+func vertexLat(lat, haverSinDLng float64) float64 {
+	cosDLng := 1 - 2*haverSinDLng
+	if cosDLng <= 0 {
+		if lat > 0 {
+			return 90
+		}
+		return -90
+	}
+	return RadsToDegree(math.Atan(math.Tan(DegreeToRads(lat)) / cosDLng))
+}
+
+type rtreeQueueItem struct {
+	node     *rtreeNode
+	distance float64
+}
+
+// rtreeQueue is a min heap of nodes and geometries by distance, geometries come before nodes at the same
+// distance and indexed points by index
+type rtreeQueue []rtreeQueueItem
+
+func (q rtreeQueue) Len() int { return len(q) }
+
+func (q rtreeQueue) Less(i, j int) bool {
+	if q[i].distance == q[j].distance {
+		pi, iok := q[i].node.geometry.(*indexedPoint)
+		pj, jok := q[j].node.geometry.(*indexedPoint)
+		if iok && jok {
+			return pi.index < pj.index
+		}
+		return q[i].node.geometry != nil && q[j].node.geometry == nil
+	}
+	return q[i].distance < q[j].distance
+}
+
+func (q rtreeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *rtreeQueue) Push(x interface{}) { *q = append(*q, x.(rtreeQueueItem)) }
+
+func (q *rtreeQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// indexedPoint is a point stored in an RTree along with its index in the slice it comes from
+type indexedPoint struct {
+	*Point
+	index int
+}
+
+// newPointTree indexes points with their index, nil and empty points are skipped
+func newPointTree(points []*Point) *RTree {
+	geometries := make([]Geometry, 0, len(points))
+	for i, point := range points {
+		if point != nil {
+			geometries = append(geometries, &indexedPoint{point, i})
+		}
+	}
+	tree := NewRTree(0)
+	tree.Load(geometries)
+	return tree
+}
+
+// searchPoints returns the points of a tree built by newPointTree within bbox, in the order of the slice
+func searchPoints(tree *RTree, bbox *BoundingBox) []*indexedPoint {
+	found := tree.Search(bbox)
+	points := make([]*indexedPoint, len(found))
+	for i, geometry := range found {
+		points[i] = geometry.(*indexedPoint)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].index < points[j].index })
+	return points
+}
+
+// newRectIndex indexes the rectangles of the planar algorithms, which are searched by their index in rects
+func newRectIndex(rects []rect) *RTree {
+	items := make([]*rtreeNode, len(rects))
+	for i, r := range rects {
+		items[i] = &rtreeNode{bbox: r.bbox(), index: i}
+	}
+	tree := NewRTree(0)
+	tree.load(items)
+	return tree
+}
+
+// search calls visit with the index of every rectangle of a tree built by newRectIndex intersecting r, until
+// visit returns false
+func (t *RTree) search(r rect, visit func(int) bool) {
+	bbox := r.bbox()
+	if intersectsBBox(&bbox, &t.root.bbox) {
+		t.root.search(&bbox, visit)
+	}
+}
+
+func (node *rtreeNode) search(bbox *BoundingBox, visit func(int) bool) bool {
+	for _, child := range node.children {
+		if !intersectsBBox(bbox, &child.bbox) {
+			continue
+		}
+		if node.leaf {
+			if !visit(child.index) {
+				return false
+			}
+		} else if !child.search(bbox, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r rect) bbox() BoundingBox {
+	return BoundingBox{r.minX, r.minY, r.maxX, r.maxY}
+}
@@ -0,0 +1,251 @@
+package turfgo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func randomPoints(n int, seed int64) []*Point {
+	r := rand.New(rand.NewSource(seed))
+	points := make([]*Point, n)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*160-80, r.Float64()*360-180)
+	}
+	return points
+}
+
+func toGeometries(points []*Point) []Geometry {
+	geometries := make([]Geometry, len(points))
+	for i, point := range points {
+		geometries[i] = point
+	}
+	return geometries
+}
+
+func bruteSearch(geometries []Geometry, bbox *BoundingBox) map[Geometry]bool {
+	found := map[Geometry]bool{}
+	for _, geometry := range geometries {
+		if intersectsBBox(bbox, geometry.Bounds()) {
+			found[geometry] = true
+		}
+	}
+	return found
+}
+
+func asSet(geometries []Geometry) map[Geometry]bool {
+	set := map[Geometry]bool{}
+	for _, geometry := range geometries {
+		set[geometry] = true
+	}
+	return set
+}
+
+func TestRTree(t *testing.T) {
+	points := toGeometries(randomPoints(1000, 1))
+	lines := []Geometry{}
+	for i := 0; i+1 < len(points); i += 2 {
+		lines = append(lines, NewLineString([]*Point{points[i].(*Point), points[i+1].(*Point)}))
+	}
+	boxes := []*BoundingBox{NewBBox(-10, -10, 10, 10), NewBBox(100, 20, 140, 25), NewBBox(-180, -90, 180, 90),
+		NewBBox(0, 85, 10, 90)}
+
+	Convey("Given bulk loaded points, should find the points within a bounding box", t, func() {
+		tree := NewRTree(0)
+		tree.Load(points)
+		So(len(tree.All()), ShouldEqual, len(points))
+		for _, bbox := range boxes {
+			result := tree.Search(bbox)
+			So(asSet(result), ShouldResemble, bruteSearch(points, bbox))
+			So(tree.Collides(bbox), ShouldEqual, len(result) > 0)
+		}
+	})
+
+	Convey("Given inserted lines, should find the lines whose bounds intersect a bounding box", t, func() {
+		tree := NewRTree(4)
+		for _, line := range lines {
+			tree.Insert(line)
+		}
+		So(len(tree.All()), ShouldEqual, len(lines))
+		for _, bbox := range boxes {
+			So(asSet(tree.Search(bbox)), ShouldResemble, bruteSearch(lines, bbox))
+		}
+	})
+
+	Convey("Given a tree, loading more geometries should merge them in", t, func() {
+		tree := NewRTree(0)
+		tree.Load(points[:900])
+		tree.Load(points[900:])
+		tree.Load(lines[:20])
+		tree.Insert(lines[20])
+		all := append(append([]Geometry{}, points...), lines[:21]...)
+		So(asSet(tree.All()), ShouldResemble, asSet(all))
+		for _, bbox := range boxes {
+			So(asSet(tree.Search(bbox)), ShouldResemble, bruteSearch(all, bbox))
+		}
+	})
+
+	Convey("Given a tree, should remove geometries", t, func() {
+		tree := NewRTree(0)
+		tree.Load(points)
+		So(tree.Remove(NewPoint(0, 0)), ShouldBeFalse)
+		for _, point := range points[:500] {
+			So(tree.Remove(point), ShouldBeTrue)
+		}
+		So(tree.Remove(points[0]), ShouldBeFalse)
+		So(asSet(tree.All()), ShouldResemble, asSet(points[500:]))
+		for _, bbox := range boxes {
+			So(asSet(tree.Search(bbox)), ShouldResemble, bruteSearch(points[500:], bbox))
+		}
+		for _, point := range points[500:] {
+			tree.Remove(point)
+		}
+		So(tree.All(), ShouldBeEmpty)
+		So(tree.Search(boxes[2]), ShouldBeEmpty)
+		tree.Insert(points[0])
+		So(tree.All(), ShouldResemble, []Geometry{points[0]})
+	})
+
+	Convey("Given geometries without points, should not index them", t, func() {
+		tree := NewRTree(0)
+		tree.Insert(NewLineString([]*Point{}))
+		tree.Load([]Geometry{NewFeature(nil, nil), nil})
+		So(tree.All(), ShouldBeEmpty)
+		So(tree.Collides(boxes[2]), ShouldBeFalse)
+	})
+
+	Convey("Given a tree of points, should return the nearest points in order", t, func() {
+		tree := NewRTree(0)
+		tree.Load(points)
+		for _, reference := range []*Point{NewPoint(0, 0), NewPoint(45, 179.9), NewPoint(-89, 10)} {
+			sorted := append([]Geometry{}, points...)
+			sort.Slice(sorted, func(i, j int) bool {
+				return Distance(reference, sorted[i].(*Point), Kilometers) < Distance(reference, sorted[j].(*Point), Kilometers)
+			})
+			So(tree.Nearest(reference, 10, math.Inf(1), Kilometers), ShouldResemble, sorted[:10])
+
+			limit := Distance(reference, sorted[25].(*Point), Kilometers)
+			So(tree.Nearest(reference, 0, limit, Kilometers), ShouldResemble, sorted[:26])
+			So(tree.Nearest(reference, 5, limit, Kilometers), ShouldResemble, sorted[:5])
+		}
+		So(tree.Nearest(NewPoint(0, 0), 3, 0, Kilometers), ShouldBeEmpty)
+		So(len(tree.Nearest(NewPoint(0, 0), 0, math.Inf(1), Kilometers)), ShouldEqual, len(points))
+	})
+
+	Convey("Given WGS84 as earth model, should return the nearest points in order", t, func() {
+		SetEarthModel(WGS84)
+		defer SetEarthModel(Sphere)
+		tree := NewRTree(0)
+		tree.Load(points)
+		reference := NewPoint(60, 20)
+		sorted := append([]Geometry{}, points...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return Distance(reference, sorted[i].(*Point), Meters) < Distance(reference, sorted[j].(*Point), Meters)
+		})
+		So(tree.Nearest(reference, 20, math.Inf(1), Meters), ShouldResemble, sorted[:20])
+	})
+
+	Convey("Given a point, bounding box distance should be a lower bound of the distance to the box", t, func() {
+		bbox := NewBBox(10, 40, 20, 50)
+		So(bboxDistance(NewPoint(45, 15), bbox, Kilometers), ShouldEqual, 0)
+		So(bboxDistance(NewPoint(30, 15), bbox, Kilometers), ShouldAlmostEqual, Distance(NewPoint(30, 15), NewPoint(40, 15), Kilometers), 0.000001)
+		So(bboxDistance(NewPoint(30, 0), bbox, Kilometers), ShouldAlmostEqual, Distance(NewPoint(30, 0), NewPoint(40, 10), Kilometers), 0.000001)
+		// at high latitudes the closest point of a meridian is north of the point
+		far := NewPoint(48, 60)
+		d := bboxDistance(far, bbox, Kilometers)
+		So(d, ShouldBeLessThan, Distance(far, NewPoint(48, 20), Kilometers))
+		for lat := 40.0; lat <= 50; lat += 0.5 {
+			So(d, ShouldBeLessThanOrEqualTo, Distance(far, NewPoint(lat, 20), Kilometers)+0.000001)
+		}
+	})
+
+	Convey("Given WGS84 as earth model, bounding box distance should be a lower bound of the distance", t, func() {
+		SetEarthModel(WGS84)
+		defer SetEarthModel(Sphere)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			west, south := r.Float64()*340-170, r.Float64()*170-85
+			bbox := NewBBox(west, south, west+r.Float64()*10, math.Min(90, south+r.Float64()*10))
+			point := NewPoint(r.Float64()*180-90, r.Float64()*360-180)
+			inside := NewPoint(bbox.South+r.Float64()*(bbox.North-bbox.South), bbox.West+r.Float64()*(bbox.East-bbox.West))
+			So(bboxDistance(point, bbox, Meters), ShouldBeLessThanOrEqualTo, Distance(point, inside, Meters))
+			So(bboxDistance(point, bbox, Degrees), ShouldBeLessThanOrEqualTo, Distance(point, inside, Degrees))
+		}
+		// along a meridian the bound is within 0.7 percent of the distance
+		So(bboxDistance(NewPoint(0, 0), NewBBox(0, 10, 1, 20), Meters), ShouldBeBetween,
+			0.993*Distance(NewPoint(0, 0), NewPoint(10, 0), Meters), Distance(NewPoint(0, 0), NewPoint(10, 0), Meters))
+	})
+}
+
+func BenchmarkRTreeLoad(b *testing.B) {
+	points := toGeometries(randomPoints(10000, 1))
+	for n := 0; n < b.N; n++ {
+		tree := NewRTree(0)
+		tree.Load(points)
+	}
+}
+
+func BenchmarkRTreeSearch(b *testing.B) {
+	tree := NewRTree(0)
+	tree.Load(toGeometries(randomPoints(10000, 1)))
+	bbox := NewBBox(-10, -10, 10, 10)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tree.Search(bbox)
+	}
+}
+
+func BenchmarkRTreeNearest(b *testing.B) {
+	tree := NewRTree(0)
+	tree.Load(toGeometries(randomPoints(10000, 1)))
+	reference := NewPoint(12, 34)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		tree.Nearest(reference, 10, math.Inf(1), Kilometers)
+	}
+}
+
+func TestRectIndex(t *testing.T) {
+	Convey("Given rectangles, should find the ones intersecting a rectangle", t, func() {
+		r := rand.New(rand.NewSource(1))
+		rects := make([]rect, 500)
+		for i := range rects {
+			x, y := r.Float64()*100, r.Float64()*100
+			rects[i] = rect{x, y, x + r.Float64()*5, y + r.Float64()*5}
+		}
+		tree := newRectIndex(rects)
+		for _, query := range []rect{{0, 0, 10, 10}, {50, 50, 50, 50}, {-10, -10, 200, 200}, {200, 200, 300, 300}} {
+			found := map[int]bool{}
+			tree.search(query, func(i int) bool {
+				found[i] = true
+				return true
+			})
+			expected := map[int]bool{}
+			queryBBox := query.bbox()
+			for i, rect := range rects {
+				if bbox := rect.bbox(); intersectsBBox(&bbox, &queryBBox) {
+					expected[i] = true
+				}
+			}
+			So(found, ShouldResemble, expected)
+		}
+	})
+
+	Convey("Given a visit returning false, should stop the search", t, func() {
+		tree := newRectIndex([]rect{{0, 0, 1, 1}, {0, 0, 1, 1}, {0, 0, 1, 1}})
+		visited := 0
+		tree.search(rect{0, 0, 1, 1}, func(int) bool {
+			visited++
+			return false
+		})
+		So(visited, ShouldEqual, 1)
+		newRectIndex(nil).search(rect{0, 0, 1, 1}, func(int) bool {
+			visited++
+			return true
+		})
+		So(visited, ShouldEqual, 1)
+	})
+}
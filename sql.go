@@ -0,0 +1,164 @@
+package turfgo
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// wgs84SRID is the srid always written by Value, turfgo coordinates are WGS 84 lat, lng. Use MarshalEWKB to
+// write another srid.
+const wgs84SRID = 4326
+
+// Scan implements sql.Scanner for a geometry column holding a point as EWKB or hex encoded EWKB
+func (p *Point) Scan(src interface{}) error {
+	return scanGeometryInto(src, p)
+}
+
+// Value implements driver.Valuer, the point is written as little endian EWKB with srid 4326, a nil point as NULL
+func (p *Point) Value() (driver.Value, error) {
+	return geometryValue(p)
+}
+
+// Scan implements sql.Scanner for a geometry column holding a multiPoint as EWKB or hex encoded EWKB
+func (p *MultiPoint) Scan(src interface{}) error {
+	return scanGeometryInto(src, p)
+}
+
+// Value implements driver.Valuer, the multiPoint is written as little endian EWKB with srid 4326, a nil multiPoint as NULL
+func (p *MultiPoint) Value() (driver.Value, error) {
+	return geometryValue(p)
+}
+
+// Scan implements sql.Scanner for a geometry column holding a lineString as EWKB or hex encoded EWKB
+func (p *LineString) Scan(src interface{}) error {
+	return scanGeometryInto(src, p)
+}
+
+// Value implements driver.Valuer, the lineString is written as little endian EWKB with srid 4326, a nil lineString as NULL
+func (p *LineString) Value() (driver.Value, error) {
+	return geometryValue(p)
+}
+
+// Scan implements sql.Scanner for a geometry column holding a multiLineString as EWKB or hex encoded EWKB
+func (p *MultiLineString) Scan(src interface{}) error {
+	return scanGeometryInto(src, p)
+}
+
+// Value implements driver.Valuer, the multiLineString is written as little endian EWKB with srid 4326, a nil multiLineString as NULL
+func (p *MultiLineString) Value() (driver.Value, error) {
+	return geometryValue(p)
+}
+
+// Scan implements sql.Scanner for a geometry column holding a polygon as EWKB or hex encoded EWKB
+func (p *Polygon) Scan(src interface{}) error {
+	return scanGeometryInto(src, p)
+}
+
+// Value implements driver.Valuer, the polygon is written as little endian EWKB with srid 4326, a nil polygon as NULL
+func (p *Polygon) Value() (driver.Value, error) {
+	return geometryValue(p)
+}
+
+// Scan implements sql.Scanner for a geometry column holding a multiPolygon as EWKB or hex encoded EWKB
+func (p *MultiPolygon) Scan(src interface{}) error {
+	return scanGeometryInto(src, p)
+}
+
+// Value implements driver.Valuer, the multiPolygon is written as little endian EWKB with srid 4326, a nil multiPolygon as NULL
+func (p *MultiPolygon) Value() (driver.Value, error) {
+	return geometryValue(p)
+}
+
+// Scan implements sql.Scanner for a geometry column holding a geometryCollection as EWKB or hex encoded EWKB
+func (p *GeometryCollection) Scan(src interface{}) error {
+	return scanGeometryInto(src, p)
+}
+
+// Value implements driver.Valuer, the geometryCollection is written as little endian EWKB with srid 4326, a nil geometryCollection as NULL
+func (p *GeometryCollection) Value() (driver.Value, error) {
+	return geometryValue(p)
+}
+
+// NullGeometry is a geometry of any type which may be NULL, to scan nullable geometry columns. Scanning into
+// a pointer to a geometry pointer, like **Point, works too and leaves it nil on NULL.
+type NullGeometry struct {
+	Geometry Geometry
+	Valid    bool // Valid is true if Geometry is not NULL
+}
+
+// Scan implements sql.Scanner, NULL leaves Geometry nil and Valid false
+func (n *NullGeometry) Scan(src interface{}) error {
+	if src == nil {
+		n.Geometry, n.Valid = nil, false
+		return nil
+	}
+	g, err := scanGeometry(src)
+	if err != nil {
+		return err
+	}
+	n.Geometry, n.Valid = g, true
+	return nil
+}
+
+// Value implements driver.Valuer, the geometry is written as little endian EWKB with srid 4326 and NULL if it
+// is not valid
+func (n NullGeometry) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return geometryValue(n.Geometry)
+}
+
+// scanGeometryInto scans a geometry into target, a pointer to a geometry of the same type
+func scanGeometryInto(src interface{}, target Geometry) error {
+	g, err := scanGeometry(src)
+	if err != nil {
+		return err
+	}
+	value, targetValue := reflect.ValueOf(g), reflect.ValueOf(target)
+	if value.Type() != targetValue.Type() {
+		return fmt.Errorf("sql: can't scan %T into %T", g, target)
+	}
+	targetValue.Elem().Set(value.Elem())
+	return nil
+}
+
+// geometryValue writes a geometry as little endian EWKB with srid 4326, or NULL for a nil geometry
+func geometryValue(g Geometry) (driver.Value, error) {
+	if g == nil {
+		return nil, nil
+	}
+	if value := reflect.ValueOf(g); value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, nil
+	}
+	return MarshalEWKB(g, binary.LittleEndian, wgs84SRID)
+}
+
+// scanGeometry reads a geometry from raw EWKB bytes, as returned by binary protocols, or from hex
+// encoded EWKB, which is how PostGIS returns geometry columns in text mode.
+func scanGeometry(src interface{}) (Geometry, error) {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		return nil, errors.New("sql: can't scan NULL into a geometry")
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return nil, fmt.Errorf("sql: can't scan %T into a geometry", src)
+	}
+	// raw EWKB always starts with a byte order flag of 0 or 1, hex starts with the character '0'
+	if len(b) > 0 && b[0] != wkbXDR && b[0] != wkbNDR {
+		decoded := make([]byte, hex.DecodedLen(len(b)))
+		if _, err := hex.Decode(decoded, b); err != nil {
+			return nil, err
+		}
+		b = decoded
+	}
+	return UnmarshalWKB(b)
+}
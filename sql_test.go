@@ -0,0 +1,190 @@
+package turfgo
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// fakeDriver stores the values passed to Exec and returns them from Query, optionally hex encoded
+// like PostGIS does for geometry columns in text mode.
+type fakeDriver struct {
+	stored    []driver.Value
+	hexOutput bool
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c.driver}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	driver *fakeDriver
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.stored = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	values := []driver.Value{}
+	for _, v := range s.driver.stored {
+		if b, ok := v.([]byte); ok && s.driver.hexOutput {
+			v = hex.EncodeToString(b)
+		}
+		values = append(values, v)
+	}
+	return &fakeRows{values: values}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	read   bool
+}
+
+func (r *fakeRows) Columns() []string {
+	columns := []string{}
+	for range r.values {
+		columns = append(columns, "geom")
+	}
+	return columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	copy(dest, r.values)
+	return nil
+}
+
+var testDriver = &fakeDriver{}
+
+func init() {
+	sql.Register("turfgo-fake", testDriver)
+}
+
+func TestSQL(t *testing.T) {
+	db, _ := sql.Open("turfgo-fake", "")
+	ring := NewLineString([]*Point{{0, 100}, {0, 101}, {1, 101}, {1, 100}, {0, 100}})
+	polygon := NewPolygon([]*LineString{ring})
+	multiPolygon := NewMultiPolygon([]*Polygon{polygon, polygon})
+	point := NewPoint(22.5, -97.8)
+	lineString := NewLineString([]*Point{{22.5, -97.8}, {22.1, -97.6}})
+	multiPoint := NewMultiPoint([]*Point{point, point})
+	multiLineString := NewMultiLineString([]*LineString{lineString})
+	collection := NewGeometryCollection([]Geometry{point, polygon})
+
+	Convey("Given geometries, should write them as ewkb with srid 4326", t, func() {
+		testDriver.hexOutput = false
+		_, err := db.Exec("INSERT", polygon)
+		So(err, ShouldBeNil)
+		expected, _ := MarshalEWKB(polygon, binary.LittleEndian, 4326)
+		So(testDriver.stored, ShouldResemble, []driver.Value{expected})
+	})
+
+	Convey("Given geometry columns, should scan them from ewkb and hex encoded ewkb", t, func() {
+		for _, hexOutput := range []bool{false, true} {
+			testDriver.hexOutput = hexOutput
+			_, err := db.Exec("INSERT", point, multiPoint, lineString, multiLineString,
+				polygon, multiPolygon, collection)
+			So(err, ShouldBeNil)
+
+			var p Point
+			var mp MultiPoint
+			var ls LineString
+			var mls MultiLineString
+			var poly Polygon
+			var mpoly MultiPolygon
+			var gc GeometryCollection
+			err = db.QueryRow("SELECT").Scan(&p, &mp, &ls, &mls, &poly, &mpoly, &gc)
+			So(err, ShouldBeNil)
+			So(&p, ShouldResemble, point)
+			So(&mp, ShouldResemble, multiPoint)
+			So(&ls, ShouldResemble, lineString)
+			So(&mls, ShouldResemble, multiLineString)
+			So(&poly, ShouldResemble, polygon)
+			So(&mpoly, ShouldResemble, multiPolygon)
+			So(&gc, ShouldResemble, collection)
+		}
+	})
+
+	Convey("Given column of another geometry type, should return error", t, func() {
+		testDriver.hexOutput = false
+		db.Exec("INSERT", point)
+		var poly Polygon
+		err := db.QueryRow("SELECT").Scan(&poly)
+		So(err, ShouldNotBeNil)
+		ewkb, _ := MarshalEWKB(point, binary.LittleEndian, 4326)
+		So(poly.Scan(ewkb).Error(), ShouldEqual, "sql: can't scan *turfgo.Point into *turfgo.Polygon")
+	})
+
+	Convey("Given invalid column values, should return error", t, func() {
+		So(new(Point).Scan(nil).Error(), ShouldEqual, "sql: can't scan NULL into a geometry")
+		So(new(Point).Scan(12).Error(), ShouldEqual, "sql: can't scan int into a geometry")
+		So(new(Point).Scan("zz").Error(), ShouldEqual, "encoding/hex: invalid byte: U+007A 'z'")
+	})
+
+	Convey("Given nil geometries, should write them as NULL", t, func() {
+		var p *Point
+		var poly *Polygon
+		var gc *GeometryCollection
+		_, err := db.Exec("INSERT", p, poly, gc, NullGeometry{}, NullGeometry{Geometry: point, Valid: true})
+		So(err, ShouldBeNil)
+		expected, _ := MarshalEWKB(point, binary.LittleEndian, 4326)
+		So(testDriver.stored, ShouldResemble, []driver.Value{nil, nil, nil, nil, expected})
+	})
+
+	Convey("Given NULL geometry columns, should scan them into null geometries and nil pointers", t, func() {
+		for _, hexOutput := range []bool{false, true} {
+			testDriver.hexOutput = hexOutput
+			var p *Point
+			_, err := db.Exec("INSERT", p, polygon, p, point)
+			So(err, ShouldBeNil)
+
+			null, valid := NullGeometry{Geometry: point, Valid: true}, NullGeometry{}
+			scanned, pointer := NewPoint(1, 2), NewPoint(1, 2)
+			err = db.QueryRow("SELECT").Scan(&null, &valid, &scanned, &pointer)
+			So(err, ShouldBeNil)
+			So(null, ShouldResemble, NullGeometry{})
+			So(valid, ShouldResemble, NullGeometry{Geometry: polygon, Valid: true})
+			So(scanned, ShouldBeNil)
+			So(pointer, ShouldResemble, point)
+		}
+	})
+}
@@ -1,5 +1,11 @@
 package turfgo
 
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
 // LineDiff take two lines and gives an array of lines by subracting second from first. Single coordinate overlaps are ignored.
 // Line should not have duplicate values.
 func LineDiff(firstLine *LineString, secondLine *LineString) []*LineString {
@@ -73,3 +79,828 @@ func containLocationPair(points []*Point, point1, point2 *Point) bool {
 	}
 	return false
 }
+
+// EndCap is the shape given to the ends of buffered lines
+type EndCap int
+
+// EndCap constants
+const (
+	// RoundCap ends lines with a half circle
+	RoundCap EndCap = iota
+	// FlatCap ends lines at their end points
+	FlatCap
+	// SquareCap ends lines with a half square
+	SquareCap
+)
+
+const defaultBufferSteps = 8
+
+// Buffer calculates a buffer around a geometry at the given distance, lines are given round end caps.
+// See BufferWithEndCap.
+func Buffer(geometry Geometry, distance float64, unit Unit, steps int) PolygonI {
+	return BufferWithEndCap(geometry, distance, unit, steps, RoundCap)
+}
+
+// BufferWithEndCap calculates a buffer around a geometry at the given distance and returns a Polygon or a
+// MultiPolygon, or nil when the buffer is empty. Steps is the number of segments used to draw a quarter
+// circle, 8 is used when it is not positive. A negative distance shrinks polygons and gives no buffer for
+// points and lines. The buffer is drawn on an azimuthal equidistant projection centered on the geometry, as
+// in turfjs, so distances are exact near the center and stretched far from it.
+func BufferWithEndCap(geometry Geometry, distance float64, unit Unit, steps int, endCap EndCap) PolygonI {
+	if steps <= 0 {
+		steps = defaultBufferSteps
+	}
+	points := geometry.GetPoints()
+	if len(points) == 0 {
+		return nil
+	}
+	b := &bufferBuilder{
+		projection: newAzimuthalEquidistant(sphericalMean(points)),
+		radius:     math.Abs(DistanceToRads(distance, unit)),
+		shrink:     distance < 0,
+		steps:      steps,
+		endCap:     endCap,
+	}
+	b.add(geometry)
+	if len(b.rings) == 0 && len(b.pieces) == 0 {
+		return nil
+	}
+
+	edges := [][2]vec{}
+	extent := b.radius
+	for _, ring := range append(b.rings, b.pieces...) {
+		for k, v := range ring {
+			edges = append(edges, [2]vec{v, ring[(k+1)%len(ring)]})
+			extent = math.Max(extent, math.Max(math.Abs(v.x), math.Abs(v.y)))
+		}
+	}
+	polygons := newRingIndex(b.rings, b.owners)
+	pieces := newConvexIndex(b.pieces)
+	inside := func(p vec) bool {
+		if b.shrink {
+			return polygons.contains(p) && !pieces.contains(p)
+		}
+		return polygons.contains(p) || pieces.contains(p)
+	}
+	return planarPolygons(overlay(edges, inside, extent*1e-10), b.projection.inverse)
+}
+
+// bufferBuilder collects the rings of the polygons and the convex pieces, circles and rectangles around
+// points and segments, whose union is the buffer
+type bufferBuilder struct {
+	projection azimuthalEquidistant
+	radius     float64
+	shrink     bool
+	steps      int
+	endCap     EndCap
+	rings      [][]vec
+	owners     []int
+	polygons   int
+	pieces     [][]vec
+}
+
+func (b *bufferBuilder) add(geometry Geometry) {
+	switch g := geometry.(type) {
+	case *Point:
+		b.addLine([]*Point{g}, RoundCap)
+	case *MultiPoint:
+		for _, point := range g.Points {
+			b.addLine([]*Point{point}, RoundCap)
+		}
+	case *LineString:
+		b.addLine(g.Points, b.endCap)
+	case *MultiLineString:
+		for _, lineString := range g.LineStrings {
+			b.addLine(lineString.Points, b.endCap)
+		}
+	case *GeometryCollection:
+		for _, member := range g.Geometries {
+			b.add(member)
+		}
+	case *Feature:
+		if g != nil && g.Geometry != nil {
+			b.add(g.Geometry)
+		}
+	case *FeatureCollection:
+		for _, feature := range g.Features {
+			b.add(feature)
+		}
+	case PolygonI:
+		for _, polygon := range g.GetPolygons() {
+			b.addPolygon(polygon)
+		}
+	}
+}
+
+func (b *bufferBuilder) project(points []*Point) []vec {
+	projected := []vec{}
+	for _, point := range points {
+		if isEmptyPoint(point) {
+			continue
+		}
+		v := b.projection.forward(point)
+		if len(projected) == 0 || v != projected[len(projected)-1] {
+			projected = append(projected, v)
+		}
+	}
+	return projected
+}
+
+func (b *bufferBuilder) addLine(points []*Point, endCap EndCap) {
+	if b.shrink || b.radius == 0 {
+		return
+	}
+	line := b.project(points)
+	if len(line) == 0 {
+		return
+	}
+	if len(line) == 1 {
+		switch endCap {
+		case RoundCap:
+			b.pieces = append(b.pieces, b.circle(line[0]))
+		case SquareCap:
+			r := b.radius
+			c := line[0]
+			b.pieces = append(b.pieces, []vec{{c.x - r, c.y - r}, {c.x + r, c.y - r}, {c.x + r, c.y + r}, {c.x - r, c.y + r}})
+		}
+		return
+	}
+	for i := 0; i < len(line)-1; i++ {
+		start, end := line[i], line[i+1]
+		if endCap == SquareCap {
+			d := end.sub(start).scale(b.radius / end.sub(start).length())
+			if i == 0 {
+				start = start.sub(d)
+			}
+			if i == len(line)-2 {
+				end = end.add(d)
+			}
+		}
+		b.addSegment(start, end)
+	}
+	for i, v := range line {
+		if endCap == RoundCap || (i > 0 && i < len(line)-1) {
+			b.pieces = append(b.pieces, b.circle(v))
+		}
+	}
+}
+
+func (b *bufferBuilder) addPolygon(polygon *Polygon) {
+	for _, lineString := range polygon.LineStrings {
+		ring := b.project(lineString.Points)
+		if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+			ring = ring[:len(ring)-1]
+		}
+		if len(ring) < 3 {
+			continue
+		}
+		b.rings = append(b.rings, ring)
+		b.owners = append(b.owners, b.polygons)
+		if b.radius == 0 {
+			continue
+		}
+		for k, v := range ring {
+			b.addSegment(v, ring[(k+1)%len(ring)])
+			b.pieces = append(b.pieces, b.circle(v))
+		}
+	}
+	b.polygons++
+}
+
+// addSegment adds the rectangle around a segment
+func (b *bufferBuilder) addSegment(start, end vec) {
+	d := end.sub(start)
+	normal := vec{-d.y, d.x}.scale(b.radius / d.length())
+	b.pieces = append(b.pieces, []vec{start.sub(normal), end.sub(normal), end.add(normal), start.add(normal)})
+}
+
+// circle returns a counter-clockwise circle around c
+func (b *bufferBuilder) circle(c vec) []vec {
+	n := 4 * b.steps
+	circle := make([]vec, n)
+	for k := range circle {
+		sin, cos := math.Sincos(2 * math.Pi * float64(k) / float64(n))
+		circle[k] = vec{c.x + b.radius*cos, c.y + b.radius*sin}
+	}
+	return circle
+}
+
+// sphericalMean returns the point in the direction of the mean of the points taken as unit vectors, unlike
+// Center it is not thrown to the other side of the earth by geometries crossing the antimeridian
+func sphericalMean(points []*Point) *Point {
+	x, y, z := float64(0), float64(0), float64(0)
+	for _, point := range points {
+		if isEmptyPoint(point) {
+			continue
+		}
+		lat, lng := DegreesToRads(point.Lat, point.Lng)
+		x += math.Cos(lat) * math.Cos(lng)
+		y += math.Cos(lat) * math.Sin(lng)
+		z += math.Sin(lat)
+	}
+	if math.Hypot(x, y) == 0 && z == 0 {
+		return Center(NewMultiPoint(points))
+	}
+	return &Point{RadsToDegree(math.Atan2(z, math.Hypot(x, y))), RadsToDegree(math.Atan2(y, x))}
+}
+
+// azimuthalEquidistant projects points on a plane where distances and bearings from the center are kept,
+// the coordinates are in radians. Longitudes of unprojected points are continuous with the center, they may
+// be outside -180 and 180.
+type azimuthalEquidistant struct {
+	lng0             float64
+	sinLat0, cosLat0 float64
+}
+
+func newAzimuthalEquidistant(center *Point) azimuthalEquidistant {
+	sinLat0, cosLat0 := math.Sincos(DegreeToRads(center.Lat))
+	return azimuthalEquidistant{DegreeToRads(center.Lng), sinLat0, cosLat0}
+}
+
+func (pr azimuthalEquidistant) forward(p *Point) vec {
+	sinLat, cosLat := math.Sincos(DegreeToRads(p.Lat))
+	sinLng, cosLng := math.Sincos(DegreeToRads(p.Lng) - pr.lng0)
+	c := math.Acos(math.Max(-1, math.Min(1, pr.sinLat0*sinLat+pr.cosLat0*cosLat*cosLng)))
+	k := float64(1)
+	if c != 0 {
+		k = c / math.Sin(c)
+	}
+	return vec{k * cosLat * sinLng, k * (pr.cosLat0*sinLat - pr.sinLat0*cosLat*cosLng)}
+}
+
+func (pr azimuthalEquidistant) inverse(v vec) *Point {
+	c := v.length()
+	if c == 0 {
+		return &Point{RadsToDegree(math.Asin(pr.sinLat0)), RadsToDegree(pr.lng0)}
+	}
+	sinC, cosC := math.Sincos(c)
+	lat := math.Asin(math.Max(-1, math.Min(1, cosC*pr.sinLat0+v.y*sinC*pr.cosLat0/c)))
+	lng := pr.lng0 + math.Atan2(v.x*sinC, c*pr.cosLat0*cosC-v.y*pr.sinLat0*sinC)
+	return &Point{RadsToDegree(lat), RadsToDegree(lng)}
+}
+
+// Simplify takes a LineString, MultiLineString, Polygon or MultiPolygon and returns a simplified copy of it,
+// computed with the Ramer-Douglas-Peucker algorithm. Tolerance is in degrees, points closer than it to the
+// simplified line are removed. Unless highQuality is set, points closer than tolerance to the previous kept
+// point are removed first, which is much faster on dense lines. Rings of polygons stay closed and keep at least
+// 4 points. Collections and features are simplified member by member, other geometries are returned as they are.
+func Simplify(geometry Geometry, tolerance float64, highQuality bool) Geometry {
+	return simplifyGeometry(geometry, tolerance, func(points []*Point, tolerance float64) []*Point {
+		if !highQuality {
+			points = simplifyRadialDistance(points, tolerance)
+		}
+		return simplifyDouglasPeucker(points, tolerance)
+	})
+}
+
+// SimplifyVisvalingam is like Simplify but uses the Visvalingam-Whyatt algorithm, which gives smoother
+// shapes. Points are removed by increasing area of the triangle they form with their neighbours, as long as
+// this area is less than the square of tolerance.
+func SimplifyVisvalingam(geometry Geometry, tolerance float64) Geometry {
+	return simplifyGeometry(geometry, tolerance, simplifyVisvalingam)
+}
+
+type simplifier func(points []*Point, tolerance float64) []*Point
+
+func simplifyGeometry(geometry Geometry, tolerance float64, simplify simplifier) Geometry {
+	switch g := geometry.(type) {
+	case *LineString:
+		return NewLineString(simplify(g.Points, tolerance))
+	case *MultiLineString:
+		lineStrings := make([]*LineString, len(g.LineStrings))
+		for i, lineString := range g.LineStrings {
+			lineStrings[i] = NewLineString(simplify(lineString.Points, tolerance))
+		}
+		return NewMultiLineString(lineStrings)
+	case *Polygon:
+		return simplifyPolygon(g, tolerance, simplify)
+	case *MultiPolygon:
+		polygons := make([]*Polygon, len(g.Polygons))
+		for i, polygon := range g.Polygons {
+			polygons[i] = simplifyPolygon(polygon, tolerance, simplify)
+		}
+		return NewMultiPolygon(polygons)
+	case *GeometryCollection:
+		geometries := make([]Geometry, len(g.Geometries))
+		for i, member := range g.Geometries {
+			geometries[i] = simplifyGeometry(member, tolerance, simplify)
+		}
+		return NewGeometryCollection(geometries)
+	case *Feature:
+		if g == nil {
+			return g
+		}
+		feature := *g
+		if g.Geometry != nil {
+			feature.Geometry = simplifyGeometry(g.Geometry, tolerance, simplify)
+		}
+		return &feature
+	case *FeatureCollection:
+		features := make([]*Feature, len(g.Features))
+		for i, feature := range g.Features {
+			features[i] = simplifyGeometry(feature, tolerance, simplify).(*Feature)
+		}
+		return &FeatureCollection{Features: features, BoundingBox: g.BoundingBox}
+	}
+	return geometry
+}
+
+// Rewind returns a copy of a geometry where the outer rings of polygons are counter-clockwise and their holes
+// clockwise, as RFC 7946 asks, or the opposite if reverse is true. The polygons of collections and features are
+// rewound too, other geometries are returned as they are.
+func Rewind(geometry Geometry, reverse bool) Geometry {
+	switch g := geometry.(type) {
+	case *Polygon:
+		return rewindPolygon(g, reverse)
+	case *MultiPolygon:
+		polygons := make([]*Polygon, len(g.Polygons))
+		for i, polygon := range g.Polygons {
+			polygons[i] = rewindPolygon(polygon, reverse)
+		}
+		return NewMultiPolygon(polygons)
+	case *GeometryCollection:
+		geometries := make([]Geometry, len(g.Geometries))
+		for i, member := range g.Geometries {
+			geometries[i] = Rewind(member, reverse)
+		}
+		return NewGeometryCollection(geometries)
+	case *Feature:
+		if g == nil {
+			return g
+		}
+		feature := *g
+		if g.Geometry != nil {
+			feature.Geometry = Rewind(g.Geometry, reverse)
+		}
+		return &feature
+	case *FeatureCollection:
+		features := make([]*Feature, len(g.Features))
+		for i, feature := range g.Features {
+			features[i] = Rewind(feature, reverse).(*Feature)
+		}
+		return &FeatureCollection{Features: features, BoundingBox: g.BoundingBox}
+	}
+	return geometry
+}
+
+func rewindPolygon(polygon *Polygon, reverse bool) *Polygon {
+	rings := make([]*LineString, len(polygon.LineStrings))
+	for i, ring := range polygon.LineStrings {
+		points := append([]*Point{}, ring.Points...)
+		// holes wind the other way
+		if IsClockwise(ring) != ((i > 0) != reverse) {
+			for a, b := 0, len(points)-1; a < b; a, b = a+1, b-1 {
+				points[a], points[b] = points[b], points[a]
+			}
+		}
+		rings[i] = NewLineString(points)
+	}
+	return NewPolygon(rings)
+}
+
+// simplifyPolygon simplifies every ring, the tolerance of a ring is halved until it keeps at least 4 points
+func simplifyPolygon(polygon *Polygon, tolerance float64, simplify simplifier) *Polygon {
+	rings := make([]*LineString, len(polygon.LineStrings))
+	for i, ring := range polygon.LineStrings {
+		points := ring.Points
+		if len(points) > 4 && !degenerateRing(points) {
+			for t := tolerance; t > 0; t /= 2 {
+				if simplified := simplify(ring.Points, t); len(simplified) >= 4 {
+					points = simplified
+					break
+				}
+			}
+		}
+		rings[i] = NewLineString(points)
+	}
+	return NewPolygon(rings)
+}
+
+// degenerateRing tells if a ring has fewer than 3 distinct points or all its points on a line, no tolerance
+// can simplify such a ring to the 4 points of a triangle
+func degenerateRing(points []*Point) bool {
+	a := pointToVec(points[0])
+	var direction vec
+	for _, point := range points[1:] {
+		d := pointToVec(point).sub(a)
+		if direction == (vec{}) {
+			direction = d
+		} else if cross(direction, d) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// simplifyRadialDistance removes the points closer than tolerance to the previous kept point
+func simplifyRadialDistance(points []*Point, tolerance float64) []*Point {
+	if len(points) <= 2 {
+		return points
+	}
+	sqTolerance := tolerance * tolerance
+	previous := points[0]
+	simplified := []*Point{previous}
+	for _, point := range points[1:] {
+		if sqDistance(point, previous) > sqTolerance {
+			simplified = append(simplified, point)
+			previous = point
+		}
+	}
+	if last := points[len(points)-1]; previous != last {
+		simplified = append(simplified, last)
+	}
+	return simplified
+}
+
+func simplifyDouglasPeucker(points []*Point, tolerance float64) []*Point {
+	if len(points) <= 2 {
+		return points
+	}
+	last := len(points) - 1
+	simplified := []*Point{points[0]}
+	simplified = simplifyDouglasPeuckerStep(points, 0, last, tolerance*tolerance, simplified)
+	return append(simplified, points[last])
+}
+
+// simplifyDouglasPeuckerStep keeps the point between first and last farthest from the segment joining them
+// if it is farther than the tolerance, and recurses on both sides of it
+func simplifyDouglasPeuckerStep(points []*Point, first, last int, sqTolerance float64, simplified []*Point) []*Point {
+	maxSqDistance := sqTolerance
+	index := -1
+	for i := first + 1; i < last; i++ {
+		if d := sqSegmentDistance(points[i], points[first], points[last]); d > maxSqDistance {
+			index, maxSqDistance = i, d
+		}
+	}
+	if index == -1 {
+		return simplified
+	}
+	if index-first > 1 {
+		simplified = simplifyDouglasPeuckerStep(points, first, index, sqTolerance, simplified)
+	}
+	simplified = append(simplified, points[index])
+	if last-index > 1 {
+		simplified = simplifyDouglasPeuckerStep(points, index, last, sqTolerance, simplified)
+	}
+	return simplified
+}
+
+func sqDistance(point1, point2 *Point) float64 {
+	dx, dy := point1.Lng-point2.Lng, point1.Lat-point2.Lat
+	return dx*dx + dy*dy
+}
+
+// sqSegmentDistance returns the square of the planar distance between a point and a segment
+func sqSegmentDistance(point, start, end *Point) float64 {
+	x, y := start.Lng, start.Lat
+	dx, dy := end.Lng-x, end.Lat-y
+	if dx != 0 || dy != 0 {
+		t := ((point.Lng-x)*dx + (point.Lat-y)*dy) / (dx*dx + dy*dy)
+		if t > 1 {
+			x, y = end.Lng, end.Lat
+		} else if t > 0 {
+			x, y = x+dx*t, y+dy*t
+		}
+	}
+	dx, dy = point.Lng-x, point.Lat-y
+	return dx*dx + dy*dy
+}
+
+func simplifyVisvalingam(points []*Point, tolerance float64) []*Point {
+	n := len(points)
+	if n <= 2 {
+		return points
+	}
+	threshold := tolerance * tolerance
+	previous, next := make([]int, n), make([]int, n)
+	areas := make([]float64, n)
+	removed := make([]bool, n)
+	queue := &areaQueue{}
+	for i := 1; i < n-1; i++ {
+		previous[i], next[i] = i-1, i+1
+		areas[i] = triangleArea(points[i-1], points[i], points[i+1])
+		heap.Push(queue, areaItem{i, areas[i]})
+	}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(areaItem)
+		if removed[item.index] || item.area != areas[item.index] {
+			// the area of the point has changed since it was queued
+			continue
+		}
+		if item.area >= threshold {
+			break
+		}
+		removed[item.index] = true
+		p, q := previous[item.index], next[item.index]
+		next[p], previous[q] = q, p
+		// the area of a neighbour is never less than the area of the removed point, so that points are
+		// removed in the order of their significance
+		if p > 0 {
+			areas[p] = math.Max(triangleArea(points[previous[p]], points[p], points[q]), item.area)
+			heap.Push(queue, areaItem{p, areas[p]})
+		}
+		if q < n-1 {
+			areas[q] = math.Max(triangleArea(points[p], points[q], points[next[q]]), item.area)
+			heap.Push(queue, areaItem{q, areas[q]})
+		}
+	}
+	simplified := []*Point{}
+	for i, point := range points {
+		if !removed[i] {
+			simplified = append(simplified, point)
+		}
+	}
+	return simplified
+}
+
+// triangleArea returns the planar area of a triangle in square degrees
+func triangleArea(a, b, c *Point) float64 {
+	return math.Abs((b.Lng-a.Lng)*(c.Lat-a.Lat)-(c.Lng-a.Lng)*(b.Lat-a.Lat)) / 2
+}
+
+type areaItem struct {
+	index int
+	area  float64
+}
+
+// areaQueue is a min heap of points by area
+type areaQueue []areaItem
+
+func (q areaQueue) Len() int            { return len(q) }
+func (q areaQueue) Less(i, j int) bool  { return q[i].area < q[j].area }
+func (q areaQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *areaQueue) Push(x interface{}) { *q = append(*q, x.(areaItem)) }
+
+func (q *areaQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// Union takes Polygons and MultiPolygons and returns their union as a Polygon or MultiPolygon, or nil when it is
+// empty. Holes are kept where no other polygon covers them.
+func Union(polygons ...PolygonI) PolygonI {
+	return booleanOverlay(polygons, func(inside []bool) bool {
+		for _, in := range inside {
+			if in {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Intersect takes two Polygons or MultiPolygons and returns the area they share as a Polygon or MultiPolygon,
+// or nil when they don't overlap. Polygons only touching each other have no intersection.
+func Intersect(polygon1, polygon2 PolygonI) PolygonI {
+	return booleanOverlay([]PolygonI{polygon1, polygon2}, func(inside []bool) bool {
+		return inside[0] && inside[1]
+	})
+}
+
+// Difference takes two Polygons or MultiPolygons and returns the area of the first not covered by the second as
+// a Polygon or MultiPolygon, or nil when nothing is left.
+func Difference(polygon1, polygon2 PolygonI) PolygonI {
+	return booleanOverlay([]PolygonI{polygon1, polygon2}, func(inside []bool) bool {
+		return inside[0] && !inside[1]
+	})
+}
+
+// SymmetricDifference takes two Polygons or MultiPolygons and returns the area covered by only one of them as a
+// Polygon or MultiPolygon, or nil when they are equal.
+func SymmetricDifference(polygon1, polygon2 PolygonI) PolygonI {
+	return booleanOverlay([]PolygonI{polygon1, polygon2}, func(inside []bool) bool {
+		return inside[0] != inside[1]
+	})
+}
+
+// booleanOverlay returns the area where keep is true, keep is given for each operand if the area is inside it.
+// Coordinates are taken as planar, as in turfjs. Rings with less than 3 distinct points are ignored, the
+// polygons of a MultiPolygon may overlap.
+func booleanOverlay(operands []PolygonI, keep func(inside []bool) bool) PolygonI {
+	edges := [][2]vec{}
+	indexes := make([]*ringIndex, len(operands))
+	bounds := rect{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for i, operand := range operands {
+		rings, owners := planarRings(operand)
+		for _, ring := range rings {
+			for k, v := range ring {
+				edges = append(edges, [2]vec{v, ring[(k+1)%len(ring)]})
+			}
+			bounds = bounds.extend(ringRect(ring))
+		}
+		indexes[i] = newRingIndex(rings, owners)
+	}
+	if len(edges) == 0 {
+		return nil
+	}
+	inside := make([]bool, len(operands))
+	regionInside := func(p vec) bool {
+		for i, index := range indexes {
+			inside[i] = index.contains(p)
+		}
+		return keep(inside)
+	}
+	return planarPolygons(overlay(edges, regionInside, planarTolerance(bounds)), vecToPoint)
+}
+
+// planarRings returns the rings of the polygons with planar coordinates and without the closing point, owners
+// gives the index of the polygon of every ring. Rings with less than 3 distinct points are left out.
+func planarRings(polygon PolygonI) (rings [][]vec, owners []int) {
+	if polygon == nil {
+		return nil, nil
+	}
+	for i, p := range polygon.GetPolygons() {
+		if p == nil {
+			continue
+		}
+		for _, lineString := range p.LineStrings {
+			ring := []vec{}
+			for _, point := range lineString.Points {
+				v := pointToVec(point)
+				if isEmptyPoint(point) || (len(ring) > 0 && ring[len(ring)-1] == v) {
+					continue
+				}
+				ring = append(ring, v)
+			}
+			if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+				ring = ring[:len(ring)-1]
+			}
+			if len(ring) < 3 {
+				continue
+			}
+			rings = append(rings, ring)
+			owners = append(owners, i)
+		}
+	}
+	return rings, owners
+}
+
+// ConvexHull takes geometries and returns the smallest convex polygon containing all their points, computed
+// with Andrew's monotone chain algorithm. The ring is counter-clockwise, points lying on its edges are left out.
+// It returns nil when the points don't span an area: when there are less than 3 distinct points or when all of
+// them are on the same line.
+func ConvexHull(geometries ...Geometry) *Polygon {
+	points := []*Point{}
+	for _, geometry := range geometries {
+		for _, point := range geometry.GetPoints() {
+			if !isEmptyPoint(point) {
+				points = append(points, point)
+			}
+		}
+	}
+	if len(points) < 3 {
+		return nil
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Lng == points[j].Lng {
+			return points[i].Lat < points[j].Lat
+		}
+		return points[i].Lng < points[j].Lng
+	})
+
+	// lower hull from west to east then upper hull back, a point is dropped when it doesn't turn left
+	hull := []*Point{}
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, point := range points {
+			for len(hull) >= start+2 &&
+				!orient(pointToVec(hull[len(hull)-2]), pointToVec(hull[len(hull)-1]), pointToVec(point)) {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, point)
+		}
+		// the last point is the first of the other half
+		hull = hull[:len(hull)-1]
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+	if len(hull) < 3 {
+		return nil
+	}
+	return NewPolygon([]*LineString{NewLineString(append(hull, hull[0]))})
+}
+
+// ConcaveHull takes points and returns a concave hull around them, as a Polygon or a MultiPolygon which may
+// have holes. The points are triangulated with a Delaunay triangulation, triangles with an edge longer than
+// maxEdge, measured with Distance in the given unit, are removed and the others are merged. With an infinite
+// maxEdge the result is the convex hull. It returns nil when no triangle is left, and so when there are less
+// than 3 distinct points or when all of them are on the same line.
+func ConcaveHull(points []*Point, maxEdge float64, unit Unit) PolygonI {
+	vertices := []vec{}
+	kept := []*Point{}
+	for _, point := range points {
+		if !isEmptyPoint(point) {
+			vertices = append(vertices, pointToVec(point))
+			kept = append(kept, point)
+		}
+	}
+	d := newDelaunay(vertices)
+	short := make([]bool, len(d.triangles)/3)
+	for t := range short {
+		short[t] = true
+		for e := 3 * t; e < 3*t+3; e++ {
+			if Distance(kept[d.triangles[e]], kept[d.triangles[nextHalfEdge(e)]], unit) > maxEdge {
+				short[t] = false
+			}
+		}
+	}
+
+	// the triangles are clockwise, their edges are reversed to have the hull on their left
+	boundary := [][2]int{}
+	for e, start := range d.triangles {
+		if short[e/3] && (d.halfEdges[e] == -1 || !short[d.halfEdges[e]/3]) {
+			boundary = append(boundary, [2]int{d.triangles[nextHalfEdge(e)], start})
+		}
+	}
+	if len(boundary) == 0 {
+		return nil
+	}
+	bounds := ringRect(vertices)
+	g := &overlayGraph{tolerance: math.Max(bounds.maxX-bounds.minX, bounds.maxY-bounds.minY) * 1e-10, vertices: vertices}
+	return planarPolygons(assemblePolygons(g, boundary), vecToPoint)
+}
+
+// Voronoi takes points and a bounding box and returns the Voronoi cell of every point clipped to the box, the
+// area closer to the point than to any other, in the order of the points. Coordinates are taken as planar. The
+// cell of a duplicated point belongs to its first occurrence, the others, and points whose cell is outside the
+// box, get nil. The box defaults to -180, -85, 180, 85 when it is nil.
+func Voronoi(points []*Point, bbox *BoundingBox) []*Polygon {
+	if bbox == nil {
+		bbox = NewBBox(-180, -85, 180, 85)
+	}
+	vertices := []vec{}
+	ids := make([]int, len(points))
+	firsts := map[vec]int{}
+	for i, point := range points {
+		ids[i] = -1
+		if isEmptyPoint(point) {
+			continue
+		}
+		v := pointToVec(point)
+		if _, ok := firsts[v]; ok {
+			continue
+		}
+		firsts[v] = len(vertices)
+		ids[i] = len(vertices)
+		vertices = append(vertices, v)
+	}
+
+	// the neighbours of a point in the Delaunay triangulation bound its cell
+	d := newDelaunay(vertices)
+	neighbours := make([][]int, len(vertices))
+	for e, start := range d.triangles {
+		end := d.triangles[nextHalfEdge(e)]
+		neighbours[start] = append(neighbours[start], end)
+		if d.halfEdges[e] == -1 {
+			neighbours[end] = append(neighbours[end], start)
+		}
+	}
+	if len(d.triangles) == 0 {
+		for k := 0; k < len(d.hull)-1; k++ {
+			neighbours[d.hull[k]] = append(neighbours[d.hull[k]], d.hull[k+1])
+			neighbours[d.hull[k+1]] = append(neighbours[d.hull[k+1]], d.hull[k])
+		}
+	}
+
+	box := []vec{{bbox.West, bbox.South}, {bbox.East, bbox.South}, {bbox.East, bbox.North}, {bbox.West, bbox.North}}
+	cells := make([]*Polygon, len(points))
+	for i, id := range ids {
+		if id == -1 {
+			continue
+		}
+		cell := box
+		for _, neighbour := range neighbours[id] {
+			p, q := vertices[id], vertices[neighbour]
+			cell = clipHalfPlane(cell, p.add(q).scale(0.5), q.sub(p))
+		}
+		if len(cell) < 3 {
+			continue
+		}
+		ring := make([]*Point, 0, len(cell)+1)
+		for _, v := range cell {
+			ring = append(ring, vecToPoint(v))
+		}
+		cells[i] = NewPolygon([]*LineString{NewLineString(append(ring, ring[0]))})
+	}
+	return cells
+}
+
+// clipHalfPlane clips a convex polygon to the half plane of the points p for which (p - origin) . normal <= 0,
+// with the Sutherland-Hodgman algorithm
+func clipHalfPlane(polygon []vec, origin, normal vec) []vec {
+	clipped := []vec{}
+	for k, current := range polygon {
+		previous := polygon[(k+len(polygon)-1)%len(polygon)]
+		dc, dp := dot(current.sub(origin), normal), dot(previous.sub(origin), normal)
+		if (dc <= 0) != (dp <= 0) {
+			clipped = append(clipped, previous.add(current.sub(previous).scale(dp/(dp-dc))))
+		}
+		if dc <= 0 {
+			clipped = append(clipped, current)
+		}
+	}
+	return clipped
+}
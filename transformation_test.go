@@ -2,9 +2,14 @@ package turfgo
 
 import (
 	. "github.com/smartystreets/goconvey/convey"
+	"math"
 	"testing"
 )
 
+// used to avoid compiler optimization
+var testResultPolygon PolygonI
+var testResultGeometry Geometry
+
 func TestLineDiff(t *testing.T) {
 	Convey("Given empty first line, should return empty array", t, func() {
 		points1 := []*Point{}
@@ -306,3 +311,445 @@ func TestLineDiffPercentage(t *testing.T) {
 	})
 
 }
+
+func TestBuffer(t *testing.T) {
+	// 10 kilometers along the equator
+	tenKm := DistanceToDegrees(10, Kilometers)
+	line := NewLineString([]*Point{NewPoint(0, 0), NewPoint(0, tenKm)})
+	square := NewPolygon([]*LineString{NewLineString([]*Point{NewPoint(0, 0), NewPoint(0, tenKm),
+		NewPoint(tenKm, tenKm), NewPoint(tenKm, 0), NewPoint(0, 0)})})
+	Convey("Given a point, should return a circle around it", t, func() {
+		center := NewPoint(12.9715987, 77.59456269999998)
+		buffer := Buffer(center, 1, Kilometers, 8)
+		polygon, ok := buffer.(*Polygon)
+		So(ok, ShouldBeTrue)
+		So(len(polygon.LineStrings), ShouldEqual, 1)
+		So(len(polygon.LineStrings[0].Points), ShouldEqual, 33)
+		for _, point := range polygon.LineStrings[0].Points {
+			So(Distance(center, point, Kilometers), ShouldAlmostEqual, 1, 0.0000001)
+		}
+	})
+
+	Convey("Given a line, should buffer it with the end caps", t, func() {
+		round := Buffer(line, 1, Kilometers, 8)
+		So(round.Type(), ShouldEqual, "Polygon")
+		So(round.Bounds().West, ShouldAlmostEqual, -DistanceToDegrees(1, Kilometers), 0.000001)
+		// 20 square kilometers and a circle, drawn with 32 segments
+		So(sphericalArea(round), ShouldAlmostEqual, 20+16*math.Sin(math.Pi/16), 0.1)
+
+		flat := BufferWithEndCap(line, 1, Kilometers, 8, FlatCap)
+		So(flat.Bounds().West, ShouldAlmostEqual, 0, 0.000001)
+		So(flat.Bounds().East, ShouldAlmostEqual, tenKm, 0.000001)
+		So(len(flat.GetPolygons()[0].LineStrings[0].Points), ShouldEqual, 5)
+		So(sphericalArea(flat), ShouldAlmostEqual, 20, 0.1)
+
+		square := BufferWithEndCap(line, 1, Kilometers, 8, SquareCap)
+		So(square.Bounds().East, ShouldAlmostEqual, tenKm+DistanceToDegrees(1, Kilometers), 0.000001)
+		So(sphericalArea(square), ShouldAlmostEqual, 24, 0.1)
+	})
+
+	Convey("Given a line turning back on itself, should return a polygon with a hole", t, func() {
+		loop := NewLineString([]*Point{NewPoint(0, 0), NewPoint(0, tenKm), NewPoint(tenKm, tenKm),
+			NewPoint(tenKm, 0), NewPoint(0, 0)})
+		buffer := Buffer(loop, 1, Kilometers, 8)
+		So(buffer.Type(), ShouldEqual, "Polygon")
+		So(len(buffer.GetPolygons()[0].LineStrings), ShouldEqual, 2)
+		// the hole is the 8 kilometers wide square inside the loop
+		So(sphericalArea(buffer), ShouldAlmostEqual, 144-64-4*(1-16*math.Sin(math.Pi/16)/4), 0.3)
+	})
+
+	Convey("Given a polygon, should grow or shrink it", t, func() {
+		grown := Buffer(square, 1, Kilometers, 8)
+		So(grown.Type(), ShouldEqual, "Polygon")
+		So(sphericalArea(grown), ShouldAlmostEqual, 140+16*math.Sin(math.Pi/16), 0.3)
+
+		shrunk := Buffer(square, -1, Kilometers, 8)
+		So(shrunk.Type(), ShouldEqual, "Polygon")
+		So(len(shrunk.GetPolygons()[0].LineStrings[0].Points), ShouldEqual, 5)
+		So(sphericalArea(shrunk), ShouldAlmostEqual, 64, 0.3)
+
+		So(Buffer(square, -6, Kilometers, 8), ShouldBeNil)
+	})
+
+	Convey("Given a polygon with a small hole, should fill the hole", t, func() {
+		oneKm := DistanceToDegrees(1, Kilometers)
+		holed := NewPolygon([]*LineString{square.LineStrings[0], NewLineString([]*Point{NewPoint(4*oneKm, 4*oneKm),
+			NewPoint(5*oneKm, 4*oneKm), NewPoint(5*oneKm, 5*oneKm), NewPoint(4*oneKm, 5*oneKm), NewPoint(4*oneKm, 4*oneKm)})})
+		buffer := Buffer(holed, 1, Kilometers, 8)
+		So(len(buffer.GetPolygons()[0].LineStrings), ShouldEqual, 1)
+	})
+
+	Convey("Given points, should merge the circles that overlap", t, func() {
+		far := NewMultiPoint([]*Point{NewPoint(0, 0), NewPoint(0, tenKm)})
+		So(Buffer(far, 1, Kilometers, 8).Type(), ShouldEqual, "MultiPolygon")
+		close := NewMultiPoint([]*Point{NewPoint(0, 0), NewPoint(0, tenKm/10)})
+		So(Buffer(close, 1, Kilometers, 8).Type(), ShouldEqual, "Polygon")
+	})
+
+	Convey("Given a featureCollection with nil features, should buffer the others", t, func() {
+		features := NewFeatureCollection([]*Feature{nil, NewFeature(NewPoint(0, 0), nil)})
+		So(Buffer(features, 1, Kilometers, 8), ShouldResemble, Buffer(NewPoint(0, 0), 1, Kilometers, 8))
+	})
+
+	Convey("Given a line and a negative distance, should return nil", t, func() {
+		So(Buffer(line, -1, Kilometers, 8), ShouldBeNil)
+		So(Buffer(NewLineString([]*Point{}), 1, Kilometers, 8), ShouldBeNil)
+	})
+
+	Convey("Given a line across the antimeridian, should keep the buffer continuous", t, func() {
+		buffer := Buffer(NewLineString([]*Point{NewPoint(0, 179.95), NewPoint(0, -179.95)}), 1, Kilometers, 8)
+		So(buffer.Type(), ShouldEqual, "Polygon")
+		So(buffer.Bounds().East-buffer.Bounds().West, ShouldBeLessThan, 1)
+	})
+}
+
+// sphericalArea returns the area of a polygon in square kilometers
+func sphericalArea(polygon PolygonI) float64 {
+	area, _ := Area(polygon, Kilometers)
+	return area
+}
+
+func BenchmarkBuffer(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		testResultPolygon = Buffer(longRoute, 50, Meters, 8)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	line := NewLineString([]*Point{{0, 0}, {0.1, 1}, {-0.1, 2}, {5, 3}, {6, 4}, {7, 5}, {8.1, 6}, {9, 7}, {9, 8}, {9, 9}})
+
+	Convey("Given a line, should simplify it with Douglas-Peucker", t, func() {
+		simplified := Simplify(line, 1, true).(*LineString)
+		So(simplified.Points, ShouldResemble, []*Point{{0, 0}, {-0.1, 2}, {5, 3}, {9, 7}, {9, 9}})
+		So(len(line.Points), ShouldEqual, 10)
+
+		simplified = Simplify(line, 1.5, true).(*LineString)
+		So(simplified.Points, ShouldResemble, []*Point{{0, 0}, {9, 9}})
+
+		// collinear points are removed even without tolerance
+		So(Simplify(line, 0, true).(*LineString).Points, ShouldHaveLength, 8)
+	})
+
+	Convey("Given a dense route, should keep its ends", t, func() {
+		for _, highQuality := range []bool{true, false} {
+			simplified := Simplify(longRoute, 0.0001, highQuality).(*LineString)
+			So(len(simplified.Points), ShouldBeLessThan, len(longRoute.Points))
+			So(simplified.Points[0], ShouldEqual, longRoute.Points[0])
+			So(simplified.Points[len(simplified.Points)-1], ShouldEqual, longRoute.Points[len(longRoute.Points)-1])
+		}
+	})
+
+	Convey("Given a line, should simplify it with Visvalingam-Whyatt", t, func() {
+		simplified := SimplifyVisvalingam(line, 1).(*LineString)
+		So(simplified.Points, ShouldResemble, []*Point{{0, 0}, {-0.1, 2}, {5, 3}, {9, 7}, {9, 9}})
+	})
+
+	Convey("Given a polygon and a large tolerance, should keep valid rings", t, func() {
+		ring := NewLineString([]*Point{{0, 0}, {0, 1}, {0.01, 2}, {1, 2}, {2, 2.01}, {2, 1}, {2, 0}, {1, 0}, {0, 0}})
+		polygon := NewPolygon([]*LineString{ring})
+		for _, simplified := range []Geometry{Simplify(polygon, 10, true), SimplifyVisvalingam(polygon, 10)} {
+			points := simplified.(*Polygon).LineStrings[0].Points
+			So(len(points), ShouldBeGreaterThanOrEqualTo, 4)
+			So(points[0], ShouldResemble, points[len(points)-1])
+		}
+		simplified := Simplify(polygon, 0.1, true).(*Polygon)
+		So(simplified.LineStrings[0].Points, ShouldResemble, []*Point{{0, 0}, {0.01, 2}, {2, 2.01}, {2, 0}, {0, 0}})
+	})
+
+	Convey("Given degenerate rings, should return them as they are", t, func() {
+		collinear := NewLineString([]*Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}, {2, 2}, {1, 1}, {0, 0}})
+		repeated := NewLineString([]*Point{{0, 0}, {0, 0}, {1, 1}, {1, 1}, {0, 0}})
+		polygon := NewPolygon([]*LineString{collinear, repeated})
+		for _, simplified := range []Geometry{Simplify(polygon, 10, true), SimplifyVisvalingam(polygon, 10)} {
+			So(simplified.(*Polygon).LineStrings[0].Points, ShouldResemble, collinear.Points)
+			So(simplified.(*Polygon).LineStrings[1].Points, ShouldResemble, repeated.Points)
+		}
+	})
+
+	Convey("Given a feature collection with nil features, should skip them", t, func() {
+		feature := NewFeature(line, nil)
+		simplified := Simplify(NewFeatureCollection([]*Feature{nil, feature}), 1, true).(*FeatureCollection)
+		So(simplified.Features[0], ShouldBeNil)
+		So(simplified.Features[1].Geometry.(*LineString).Points, ShouldHaveLength, 5)
+	})
+
+	Convey("Given a feature, should simplify its geometry and keep its properties", t, func() {
+		feature := NewFeature(NewMultiLineString([]*LineString{line}), map[string]interface{}{"name": "route"})
+		simplified := Simplify(NewFeatureCollection([]*Feature{feature}), 1, true).(*FeatureCollection)
+		So(simplified.Features[0].Properties, ShouldResemble, feature.Properties)
+		So(simplified.Features[0].Geometry.(*MultiLineString).LineStrings[0].Points, ShouldHaveLength, 5)
+		So(feature.Geometry.(*MultiLineString).LineStrings[0].Points, ShouldHaveLength, 10)
+	})
+
+	Convey("Given a point, should return it", t, func() {
+		point := NewPoint(1, 2)
+		So(Simplify(point, 1, true), ShouldEqual, point)
+	})
+}
+
+func BenchmarkSimplify(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		testResultGeometry = Simplify(longRoute, 0.0001, false)
+	}
+}
+
+// box returns an axis aligned rectangle, with holes given as west, south, east and north too. All its rings are
+// counter-clockwise.
+func box(west, south, east, north float64, holes ...[4]float64) *Polygon {
+	rings := []*LineString{NewLineString([]*Point{{south, west}, {south, east}, {north, east}, {north, west}, {south, west}})}
+	for _, hole := range holes {
+		rings = append(rings, box(hole[0], hole[1], hole[2], hole[3]).LineStrings[0])
+	}
+	return NewPolygon(rings)
+}
+
+func planarArea(polygon PolygonI) float64 {
+	area := float64(0)
+	for _, p := range polygon.GetPolygons() {
+		for i, lineString := range p.LineStrings {
+			ring := []vec{}
+			for _, point := range lineString.Points[1:] {
+				ring = append(ring, pointToVec(point))
+			}
+			if i == 0 {
+				area += math.Abs(signedArea(ring))
+			} else {
+				area -= math.Abs(signedArea(ring))
+			}
+		}
+	}
+	return area
+}
+
+func TestBooleanOverlay(t *testing.T) {
+	a := box(0, 0, 2, 2)
+	b := box(1, 1, 3, 3)
+
+	Convey("Given overlapping polygons, should compute union, intersection and differences", t, func() {
+		union := Union(a, b)
+		So(union.Type(), ShouldEqual, "Polygon")
+		So(union.GetPolygons()[0].LineStrings[0].Points, ShouldHaveLength, 9)
+		So(planarArea(union), ShouldAlmostEqual, 7, 0.000001)
+
+		intersection := Intersect(a, b)
+		So(intersection.Type(), ShouldEqual, "Polygon")
+		So(intersection.GetPolygons()[0].LineStrings[0].Points, ShouldHaveLength, 5)
+		So(intersection.Bounds(), ShouldResemble, NewBBox(1, 1, 2, 2))
+
+		difference := Difference(a, b)
+		So(difference.GetPolygons()[0].LineStrings[0].Points, ShouldHaveLength, 7)
+		So(planarArea(difference), ShouldAlmostEqual, 3, 0.000001)
+
+		// the two parts only touch at their corners
+		xor := SymmetricDifference(a, b)
+		So(xor.Type(), ShouldEqual, "MultiPolygon")
+		So(xor.GetPolygons(), ShouldHaveLength, 2)
+		So(planarArea(xor), ShouldAlmostEqual, 6, 0.000001)
+	})
+
+	Convey("Given polygons sharing an edge, should merge them", t, func() {
+		left := box(0, 0, 1, 1)
+		right := box(1, 0, 2, 1)
+		union := Union(left, right)
+		So(union.GetPolygons()[0].LineStrings[0].Points, ShouldHaveLength, 5)
+		So(union.Bounds(), ShouldResemble, NewBBox(0, 0, 2, 1))
+		So(Intersect(left, right), ShouldBeNil)
+	})
+
+	Convey("Given polygons with holes, should keep or fill the holes", t, func() {
+		holed := box(0, 0, 4, 4, [4]float64{1, 1, 3, 3})
+		filling := box(1, 1, 3, 3)
+		union := Union(holed, filling)
+		So(union.GetPolygons()[0].LineStrings, ShouldHaveLength, 1)
+		So(planarArea(union), ShouldAlmostEqual, 16, 0.000001)
+		So(Intersect(holed, filling), ShouldBeNil)
+
+		difference := Difference(box(0, 0, 4, 4), filling)
+		So(difference.GetPolygons()[0].LineStrings, ShouldHaveLength, 2)
+		So(planarArea(difference), ShouldAlmostEqual, 12, 0.000001)
+
+		partly := Union(holed, box(2, 1, 5, 3))
+		So(partly.GetPolygons()[0].LineStrings, ShouldHaveLength, 2)
+		So(planarArea(partly), ShouldAlmostEqual, 12+2+2, 0.000001)
+	})
+
+	Convey("Given multiPolygons, should combine all their polygons", t, func() {
+		multi := NewMultiPolygon([]*Polygon{a, box(5, 5, 6, 6)})
+		union := Union(multi, b)
+		So(union.Type(), ShouldEqual, "MultiPolygon")
+		So(planarArea(union), ShouldAlmostEqual, 8, 0.000001)
+		So(planarArea(Intersect(multi, box(1.5, 1.5, 5.5, 5.5))),
+			ShouldAlmostEqual, 0.25+0.25, 0.000001)
+	})
+
+	Convey("Given degenerate polygons, should ignore them", t, func() {
+		flat := NewPolygon([]*LineString{NewLineString([]*Point{{0, 0}, {1, 1}, {2, 2}, {0, 0}})})
+		So(Union(flat), ShouldBeNil)
+		So(Union(a, flat).Bounds(), ShouldResemble, a.Bounds())
+		So(Union(NewPolygon([]*LineString{NewLineString([]*Point{{0, 0}, {0, 0}})})), ShouldBeNil)
+		So(Difference(a, a), ShouldBeNil)
+		So(SymmetricDifference(a, a), ShouldBeNil)
+	})
+}
+
+func BenchmarkUnion(b *testing.B) {
+	b.StopTimer()
+	circle1 := Buffer(NewPoint(0, 0), 1, Kilometers, 64)
+	circle2 := Buffer(NewPoint(0, 0.01), 1, Kilometers, 64)
+	b.StartTimer()
+	for n := 0; n < b.N; n++ {
+		testResultPolygon = Union(circle1, circle2)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	Convey("Given points, should return the convex hull counter-clockwise", t, func() {
+		points := NewMultiPoint([]*Point{{0, 0}, {1, 1}, {0, 2}, {2, 2}, {2, 0}, {0.5, 1.5}, {0, 1}})
+		hull := ConvexHull(points, NewLineString([]*Point{{1, 3}, {1, 1}}))
+		So(hull.LineStrings[0].Points, ShouldResemble, []*Point{{0, 0}, {0, 2}, {1, 3}, {2, 2}, {2, 0}, {0, 0}})
+	})
+
+	Convey("Given a polygon, should return the hull of its outer ring", t, func() {
+		polygon := box(0, 0, 2, 2, [4]float64{0.5, 0.5, 1, 1})
+		So(ConvexHull(polygon).Bounds(), ShouldResemble, polygon.Bounds())
+		So(ConvexHull(polygon).LineStrings[0].Points, ShouldHaveLength, 5)
+	})
+
+	Convey("Given collinear or too few points, should return nil", t, func() {
+		So(ConvexHull(NewLineString([]*Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}})), ShouldBeNil)
+		So(ConvexHull(NewPoint(0, 0), NewPoint(1, 1)), ShouldBeNil)
+		So(ConvexHull(NewPoint(1, 1), NewPoint(1, 1), NewPoint(1, 1)), ShouldBeNil)
+		So(ConvexHull(), ShouldBeNil)
+	})
+}
+
+func TestConcaveHull(t *testing.T) {
+	km := DistanceToDegrees(1, Kilometers)
+	// a grid of 4 by 4 kilometers with no point in its middle
+	grid := []*Point{}
+	for x := 0; x <= 4; x++ {
+		for y := 0; y <= 4; y++ {
+			if x != 2 || y != 2 {
+				grid = append(grid, NewPoint(float64(y)*km, float64(x)*km))
+			}
+		}
+	}
+	Convey("Given points, should leave out the triangles with long edges", t, func() {
+		hull := ConcaveHull(grid, 1.5, Kilometers)
+		So(hull.Type(), ShouldEqual, "Polygon")
+		// the hole is the square of the 4 points around the middle, turned by 45 degrees
+		So(hull.GetPolygons()[0].LineStrings, ShouldHaveLength, 2)
+		So(hull.GetPolygons()[0].LineStrings[0].Points, ShouldHaveLength, 5)
+		So(hull.GetPolygons()[0].LineStrings[1].Points, ShouldHaveLength, 5)
+		So(sphericalArea(hull), ShouldAlmostEqual, 14, 0.1)
+	})
+
+	Convey("Given an infinite maxEdge, should return the convex hull", t, func() {
+		hull := ConcaveHull(grid, math.Inf(1), Kilometers)
+		So(hull.GetPolygons()[0].LineStrings, ShouldHaveLength, 1)
+		So(sphericalArea(hull), ShouldAlmostEqual, 16, 0.1)
+	})
+
+	Convey("Given distant clusters, should return a multiPolygon", t, func() {
+		clusters := append([]*Point{}, grid...)
+		for _, point := range grid {
+			clusters = append(clusters, NewPoint(point.Lat, point.Lng+10*km))
+		}
+		hull := ConcaveHull(clusters, 1.5, Kilometers)
+		So(hull.Type(), ShouldEqual, "MultiPolygon")
+		So(sphericalArea(hull), ShouldAlmostEqual, 28, 0.2)
+	})
+
+	Convey("Given collinear points or a small maxEdge, should return nil", t, func() {
+		So(ConcaveHull(grid, 0.5, Kilometers), ShouldBeNil)
+		So(ConcaveHull([]*Point{{0, 0}, {1, 1}, {2, 2}}, 1000, Kilometers), ShouldBeNil)
+		So(ConcaveHull([]*Point{{0, 0}, {0, 0}, {0, 0}, {1, 1}}, 1000, Kilometers), ShouldBeNil)
+		So(ConcaveHull([]*Point{}, 1000, Kilometers), ShouldBeNil)
+	})
+}
+
+func TestVoronoi(t *testing.T) {
+	box := NewBBox(-2, -2, 2, 2)
+
+	Convey("Given two points, should split the box between them", t, func() {
+		cells := Voronoi([]*Point{{0, -1}, {0, 1}}, box)
+		So(cells, ShouldHaveLength, 2)
+		So(cells[0].Bounds(), ShouldResemble, NewBBox(-2, -2, 0, 2))
+		So(cells[1].Bounds(), ShouldResemble, NewBBox(0, -2, 2, 2))
+	})
+
+	Convey("Given random points, should cover the box with cells containing their points", t, func() {
+		points := []*Point{}
+		for i := 0; i < 50; i++ {
+			points = append(points, NewPoint(math.Sin(float64(i)*7.3)*1.9, math.Cos(float64(i)*3.1)*1.9))
+		}
+		cells := Voronoi(points, box)
+		total := float64(0)
+		for i, cell := range cells {
+			So(Inside(points[i], cell), ShouldBeTrue)
+			total += planarArea(cell)
+		}
+		So(total, ShouldAlmostEqual, 16, 0.000001)
+	})
+
+	Convey("Given collinear points, should return strips", t, func() {
+		cells := Voronoi([]*Point{{0, 0}, {0, 1}, {0, -1}}, box)
+		So(cells[0].Bounds(), ShouldResemble, NewBBox(-0.5, -2, 0.5, 2))
+		So(cells[1].Bounds(), ShouldResemble, NewBBox(0.5, -2, 2, 2))
+	})
+
+	Convey("Given a duplicated point or a cell outside the box, should return nil for it", t, func() {
+		cells := Voronoi([]*Point{{0, 0}, {0, 0}, {0, 100}}, box)
+		So(cells[0].Bounds(), ShouldResemble, box)
+		So(cells[1], ShouldBeNil)
+		So(cells[2], ShouldBeNil)
+	})
+
+	Convey("Given no box, should use the world", t, func() {
+		cells := Voronoi([]*Point{{0, 0}}, nil)
+		So(cells[0].Bounds(), ShouldResemble, NewBBox(-180, -85, 180, 85))
+	})
+}
+
+func TestRewind(t *testing.T) {
+	outer := NewLineString([]*Point{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}})
+	hole := NewLineString([]*Point{{2, 2}, {2, 4}, {4, 4}, {4, 2}, {2, 2}})
+	polygon := NewPolygon([]*LineString{outer, hole})
+
+	Convey("Given a polygon with mixed winding, should put outer rings counter-clockwise and holes clockwise", t, func() {
+		So(IsClockwise(outer), ShouldBeTrue)
+		So(IsClockwise(hole), ShouldBeFalse)
+		rewound := Rewind(polygon, false).(*Polygon)
+		So(IsClockwise(rewound.LineStrings[0]), ShouldBeFalse)
+		So(IsClockwise(rewound.LineStrings[1]), ShouldBeTrue)
+		So(rewound.LineStrings[0].Points, ShouldResemble, []*Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}})
+		So(rewound.LineStrings[1].Points, ShouldResemble, []*Point{{2, 2}, {4, 2}, {4, 4}, {2, 4}, {2, 2}})
+		So(outer.Points[1], ShouldResemble, &Point{10, 0})
+
+		reversed := Rewind(rewound, true).(*Polygon)
+		So(reversed.LineStrings[0].Points, ShouldResemble, outer.Points)
+		So(reversed.LineStrings[1].Points, ShouldResemble, hole.Points)
+		So(Rewind(rewound, false), ShouldResemble, rewound)
+	})
+
+	Convey("Given collections, should rewind their polygons and keep other geometries", t, func() {
+		line := NewLineString([]*Point{{0, 0}, {1, 1}})
+		multi := NewMultiPolygon([]*Polygon{polygon, polygon})
+		feature := NewFeature(NewGeometryCollection([]Geometry{multi, line}), map[string]interface{}{"name": "a"})
+		collection := NewFeatureCollection([]*Feature{feature})
+		rewound := Rewind(collection, false).(*FeatureCollection)
+		members := rewound.Features[0].Geometry.(*GeometryCollection).Geometries
+		for _, p := range members[0].(*MultiPolygon).Polygons {
+			So(IsClockwise(p.LineStrings[0]), ShouldBeFalse)
+			So(IsClockwise(p.LineStrings[1]), ShouldBeTrue)
+		}
+		So(members[1], ShouldEqual, line)
+		So(rewound.Features[0].Properties, ShouldResemble, feature.Properties)
+		So(multi.Polygons[0].LineStrings[0], ShouldEqual, outer)
+	})
+	Convey("Given a featureCollection with nil features, should keep them nil", t, func() {
+		rewound := Rewind(NewFeatureCollection([]*Feature{nil, NewFeature(polygon, nil)}), false).(*FeatureCollection)
+		So(rewound.Features[0], ShouldBeNil)
+		So(IsClockwise(rewound.Features[1].Geometry.(*Polygon).LineStrings[0]), ShouldBeFalse)
+	})
+}
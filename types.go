@@ -44,14 +44,35 @@ var areaFactors = map[Unit]float64{
 	Inches:      1550.003100006,
 }
 
-//Geometry is geoJson geometry
+// GeoJSON type names returned by Geometry.Type
+const (
+	geoJSONPoint              = "Point"
+	geoJSONMultiPoint         = "MultiPoint"
+	geoJSONLineString         = "LineString"
+	geoJSONMultiLineString    = "MultiLineString"
+	geoJSONPolygon            = "Polygon"
+	geoJSONMultiPolygon       = "MultiPolygon"
+	geoJSONGeometryCollection = "GeometryCollection"
+	geoJSONFeature            = "Feature"
+	geoJSONFeatureCollection  = "FeatureCollection"
+)
+
+//Geometry is geoJson geometry. Types outside the package can implement it to be used with
+//functions like Extent, Center and Expand.
 type Geometry interface {
-	getPoints() []*Point
+	// Type returns the geojson type name of the geometry
+	Type() string
+	// GetPoints returns all the points of the geometry
+	GetPoints() []*Point
+	// Bounds returns the bounding box of the geometry
+	Bounds() *BoundingBox
 }
 
-//PolygonI is geoJson polygon
+//PolygonI is geoJson polygon. Types outside the package can implement it to be used with Inside and Within.
 type PolygonI interface {
-	getPolygons() []*Polygon
+	Geometry
+	// GetPolygons returns all the polygons of the geometry
+	GetPolygons() []*Polygon
 }
 
 //A Point on earth
@@ -60,10 +81,21 @@ type Point struct {
 	Lng float64
 }
 
-func (p *Point) getPoints() []*Point {
+// GetPoints returns a slice holding only the point
+func (p *Point) GetPoints() []*Point {
 	return []*Point{p}
 }
 
+// Type returns the geojson type name of point
+func (p *Point) Type() string {
+	return geoJSONPoint
+}
+
+// Bounds returns the bounding box of point
+func (p *Point) Bounds() *BoundingBox {
+	return pointsExtent(p.GetPoints())
+}
+
 //NewPoint creates a new point for given lat, lng
 func NewPoint(lat float64, lon float64) *Point {
 	return &Point{lat, lon}
@@ -74,10 +106,21 @@ type MultiPoint struct {
 	Points []*Point
 }
 
-func (p *MultiPoint) getPoints() []*Point {
+// GetPoints returns all the points of multiPoint
+func (p *MultiPoint) GetPoints() []*Point {
 	return p.Points
 }
 
+// Type returns the geojson type name of multiPoint
+func (p *MultiPoint) Type() string {
+	return geoJSONMultiPoint
+}
+
+// Bounds returns the bounding box of multiPoint
+func (p *MultiPoint) Bounds() *BoundingBox {
+	return pointsExtent(p.GetPoints())
+}
+
 //NewMultiPoint creates a new multiPoint for given points
 func NewMultiPoint(points []*Point) *MultiPoint {
 	return &MultiPoint{Points: points}
@@ -88,10 +131,21 @@ type LineString struct {
 	Points []*Point
 }
 
-func (p *LineString) getPoints() []*Point {
+// GetPoints returns all the points of lineString
+func (p *LineString) GetPoints() []*Point {
 	return p.Points
 }
 
+// Type returns the geojson type name of lineString
+func (p *LineString) Type() string {
+	return geoJSONLineString
+}
+
+// Bounds returns the bounding box of lineString
+func (p *LineString) Bounds() *BoundingBox {
+	return pointsExtent(p.GetPoints())
+}
+
 //NewLineString creates a new lineString for given points
 func NewLineString(points []*Point) *LineString {
 	return &LineString{Points: points}
@@ -102,14 +156,25 @@ type MultiLineString struct {
 	LineStrings []*LineString
 }
 
-func (p *MultiLineString) getPoints() []*Point {
+// GetPoints returns all the points of multiLineString
+func (p *MultiLineString) GetPoints() []*Point {
 	points := []*Point{}
 	for _, lineString := range p.LineStrings {
-		points = append(points, lineString.getPoints()...)
+		points = append(points, lineString.GetPoints()...)
 	}
 	return points
 }
 
+// Type returns the geojson type name of multiLineString
+func (p *MultiLineString) Type() string {
+	return geoJSONMultiLineString
+}
+
+// Bounds returns the bounding box of multiLineString
+func (p *MultiLineString) Bounds() *BoundingBox {
+	return pointsExtent(p.GetPoints())
+}
+
 //NewMultiLineString creates a new multiLineString for given lineStrings
 func NewMultiLineString(lineStrings []*LineString) *MultiLineString {
 	return &MultiLineString{LineStrings: lineStrings}
@@ -120,15 +185,27 @@ type Polygon struct {
 	LineStrings []*LineString
 }
 
-func (p *Polygon) getPoints() []*Point {
+// GetPoints returns all the points of polygon
+func (p *Polygon) GetPoints() []*Point {
 	points := []*Point{}
 	for _, lineString := range p.LineStrings {
-		points = append(points, lineString.getPoints()...)
+		points = append(points, lineString.GetPoints()...)
 	}
 	return points
 }
 
-func (p *Polygon) getPolygons() []*Polygon {
+// Type returns the geojson type name of polygon
+func (p *Polygon) Type() string {
+	return geoJSONPolygon
+}
+
+// Bounds returns the bounding box of polygon
+func (p *Polygon) Bounds() *BoundingBox {
+	return pointsExtent(p.GetPoints())
+}
+
+// GetPolygons returns all the polygons of polygon
+func (p *Polygon) GetPolygons() []*Polygon {
 	return []*Polygon{p}
 }
 
@@ -142,15 +219,27 @@ type MultiPolygon struct {
 	Polygons []*Polygon
 }
 
-func (p *MultiPolygon) getPoints() []*Point {
+// GetPoints returns all the points of multiPolygon
+func (p *MultiPolygon) GetPoints() []*Point {
 	points := []*Point{}
 	for _, polygon := range p.Polygons {
-		points = append(points, polygon.getPoints()...)
+		points = append(points, polygon.GetPoints()...)
 	}
 	return points
 }
 
-func (p *MultiPolygon) getPolygons() []*Polygon {
+// Type returns the geojson type name of multiPolygon
+func (p *MultiPolygon) Type() string {
+	return geoJSONMultiPolygon
+}
+
+// Bounds returns the bounding box of multiPolygon
+func (p *MultiPolygon) Bounds() *BoundingBox {
+	return pointsExtent(p.GetPoints())
+}
+
+// GetPolygons returns all the polygons of multiPolygon
+func (p *MultiPolygon) GetPolygons() []*Polygon {
 	return p.Polygons
 }
 
@@ -159,6 +248,130 @@ func NewMultiPolygon(polygons []*Polygon) *MultiPolygon {
 	return &MultiPolygon{Polygons: polygons}
 }
 
+// GeometryCollection geojson type
+type GeometryCollection struct {
+	Geometries []Geometry
+}
+
+// GetPoints returns all the points of geometryCollection
+func (p *GeometryCollection) GetPoints() []*Point {
+	points := []*Point{}
+	for _, geometry := range p.Geometries {
+		points = append(points, geometry.GetPoints()...)
+	}
+	return points
+}
+
+// Type returns the geojson type name of geometryCollection
+func (p *GeometryCollection) Type() string {
+	return geoJSONGeometryCollection
+}
+
+// Bounds returns the bounding box of geometryCollection
+func (p *GeometryCollection) Bounds() *BoundingBox {
+	return pointsExtent(p.GetPoints())
+}
+
+// GetPolygons returns the polygons of the polygons and multiPolygons held by geometryCollection,
+// other geometries are skipped. A Go type can't implement PolygonI only when it holds polygons, so every
+// geometryCollection is a PolygonI: one without polygons has none, its Area is 0 and polygon overlays like
+// Union treat it as empty.
+func (p *GeometryCollection) GetPolygons() []*Polygon {
+	polygons := []*Polygon{}
+	for _, geometry := range p.Geometries {
+		if polygon, ok := geometry.(PolygonI); ok {
+			polygons = append(polygons, polygon.GetPolygons()...)
+		}
+	}
+	return polygons
+}
+
+// NewGeometryCollection creates a new geometryCollection for given geometries
+func NewGeometryCollection(geometries []Geometry) *GeometryCollection {
+	return &GeometryCollection{Geometries: geometries}
+}
+
+// Feature geojson type, a geometry along with its id and properties
+type Feature struct {
+	ID          interface{}
+	Geometry    Geometry
+	Properties  map[string]interface{}
+	BoundingBox *BoundingBox
+}
+
+// GetPoints returns all the points of feature, a nil feature has none
+func (f *Feature) GetPoints() []*Point {
+	if f == nil || f.Geometry == nil {
+		return []*Point{}
+	}
+	return f.Geometry.GetPoints()
+}
+
+// Type returns the geojson type name of feature
+func (f *Feature) Type() string {
+	return geoJSONFeature
+}
+
+// Bounds returns the bounding box of feature
+func (f *Feature) Bounds() *BoundingBox {
+	return pointsExtent(f.GetPoints())
+}
+
+// GetPolygons returns all the polygons of feature, a nil feature has none
+func (f *Feature) GetPolygons() []*Polygon {
+	if f == nil {
+		return []*Polygon{}
+	}
+	if polygon, ok := f.Geometry.(PolygonI); ok {
+		return polygon.GetPolygons()
+	}
+	return []*Polygon{}
+}
+
+// NewFeature creates a new feature for given geometry and properties
+func NewFeature(geometry Geometry, properties map[string]interface{}) *Feature {
+	return &Feature{Geometry: geometry, Properties: properties}
+}
+
+// FeatureCollection geojson type
+type FeatureCollection struct {
+	Features    []*Feature
+	BoundingBox *BoundingBox
+}
+
+// GetPoints returns all the points of featureCollection
+func (fc *FeatureCollection) GetPoints() []*Point {
+	points := []*Point{}
+	for _, feature := range fc.Features {
+		points = append(points, feature.GetPoints()...)
+	}
+	return points
+}
+
+// Type returns the geojson type name of featureCollection
+func (fc *FeatureCollection) Type() string {
+	return geoJSONFeatureCollection
+}
+
+// Bounds returns the bounding box of featureCollection
+func (fc *FeatureCollection) Bounds() *BoundingBox {
+	return pointsExtent(fc.GetPoints())
+}
+
+// GetPolygons returns all the polygons of featureCollection
+func (fc *FeatureCollection) GetPolygons() []*Polygon {
+	polygons := []*Polygon{}
+	for _, feature := range fc.Features {
+		polygons = append(polygons, feature.GetPolygons()...)
+	}
+	return polygons
+}
+
+// NewFeatureCollection creates a new featureCollection for given features
+func NewFeatureCollection(features []*Feature) *FeatureCollection {
+	return &FeatureCollection{Features: features}
+}
+
 // BoundingBox represent a bbox
 type BoundingBox struct {
 	West  float64
@@ -6,10 +6,47 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+type vehicleTrace struct {
+	pings []*Point
+}
+
+func (v *vehicleTrace) Type() string {
+	return "VehicleTrace"
+}
+
+func (v *vehicleTrace) GetPoints() []*Point {
+	return v.pings
+}
+
+func (v *vehicleTrace) Bounds() *BoundingBox {
+	return Extent(NewMultiPoint(v.pings))
+}
+
+type zone struct {
+	name     string
+	boundary *Polygon
+}
+
+func (z *zone) Type() string {
+	return "Zone"
+}
+
+func (z *zone) GetPoints() []*Point {
+	return z.boundary.GetPoints()
+}
+
+func (z *zone) Bounds() *BoundingBox {
+	return z.boundary.Bounds()
+}
+
+func (z *zone) GetPolygons() []*Polygon {
+	return []*Polygon{z.boundary}
+}
+
 func TestGetPoints(t *testing.T) {
 	Convey("For a given point, should return points array", t, func() {
 		p := &Point{114.175329, 22.2524}
-		So(p.getPoints(), ShouldResemble, []*Point{p})
+		So(p.GetPoints(), ShouldResemble, []*Point{p})
 	})
 
 	Convey("For a given lineString, should return points array", t, func() {
@@ -18,7 +55,7 @@ func TestGetPoints(t *testing.T) {
 		point3 := &Point{35.463245, -97.508269}
 		points := []*Point{point1, point2, point3}
 		lineString := NewLineString(points)
-		So(lineString.getPoints(), ShouldResemble, points)
+		So(lineString.GetPoints(), ShouldResemble, points)
 	})
 
 	Convey("For a given multilineString, should return points array", t, func() {
@@ -31,7 +68,7 @@ func TestGetPoints(t *testing.T) {
 		lineString1 := NewLineString(points1)
 		lineString2 := NewLineString(points2)
 		multiLineString := NewMultiLineString([]*LineString{lineString1, lineString2})
-		So(multiLineString.getPoints(), ShouldResemble, append(points1, points2...))
+		So(multiLineString.GetPoints(), ShouldResemble, append(points1, points2...))
 	})
 
 	Convey("For a given polygon, should return points array", t, func() {
@@ -50,7 +87,125 @@ func TestGetPoints(t *testing.T) {
 		multiPolygon := NewMultiPolygon([]*Polygon{polygon1, polygon2})
 		result := append(points1, points2...)
 		result = append(result, points3...)
-		So(multiPolygon.getPoints(), ShouldResemble, result)
+		So(multiPolygon.GetPoints(), ShouldResemble, result)
+	})
+
+}
+
+func TestFeature(t *testing.T) {
+	point1 := &Point{0, 0}
+	point2 := &Point{0, 10}
+	point3 := &Point{10, 10}
+	ring := NewLineString([]*Point{point1, point2, point3, point1})
+	polygon := NewPolygon([]*LineString{ring})
+
+	Convey("For a given feature, should return points and polygons of its geometry", t, func() {
+		feature := NewFeature(polygon, map[string]interface{}{"name": "zone"})
+		So(feature.GetPoints(), ShouldResemble, polygon.GetPoints())
+		So(feature.GetPolygons(), ShouldResemble, []*Polygon{polygon})
+	})
+
+	Convey("For a feature without polygon geometry, should return no polygons", t, func() {
+		So(NewFeature(point1, nil).GetPolygons(), ShouldResemble, []*Polygon{})
+		So(NewFeature(nil, nil).GetPoints(), ShouldResemble, []*Point{})
+	})
+
+	Convey("For a given featureCollection, should return points and polygons of all features", t, func() {
+		fc := NewFeatureCollection([]*Feature{NewFeature(point3, nil), NewFeature(polygon, nil)})
+		So(fc.GetPoints(), ShouldResemble, append([]*Point{point3}, polygon.GetPoints()...))
+		So(fc.GetPolygons(), ShouldResemble, []*Polygon{polygon})
 	})
 
+	Convey("For a featureCollection with nil features, should skip them", t, func() {
+		fc := NewFeatureCollection([]*Feature{nil, NewFeature(polygon, nil)})
+		So(fc.GetPoints(), ShouldResemble, polygon.GetPoints())
+		So(fc.GetPolygons(), ShouldResemble, []*Polygon{polygon})
+	})
+
+	Convey("Features should be accepted wherever geometries are", t, func() {
+		feature := NewFeature(polygon, nil)
+		So(Extent(feature), ShouldResemble, NewBBox(0, 0, 10, 10))
+		So(Inside(NewPoint(2, 5), feature), ShouldBeTrue)
+		So(Inside(NewPoint(5, 2), feature), ShouldBeFalse)
+	})
+}
+
+func TestGeometryCollection(t *testing.T) {
+	point1 := &Point{0, 0}
+	point2 := &Point{0, 10}
+	point3 := &Point{10, 10}
+	ring := NewLineString([]*Point{point1, point2, point3, point1})
+	polygon := NewPolygon([]*LineString{ring})
+	lineString := NewLineString([]*Point{point2, point3})
+
+	Convey("For a given geometryCollection, should return points of all geometries", t, func() {
+		gc := NewGeometryCollection([]Geometry{point3, lineString, polygon})
+		expected := append([]*Point{point3, point2, point3}, polygon.GetPoints()...)
+		So(gc.GetPoints(), ShouldResemble, expected)
+	})
+
+	Convey("For a given geometryCollection, should return only the polygons it holds", t, func() {
+		multiPolygon := NewMultiPolygon([]*Polygon{polygon, polygon})
+		gc := NewGeometryCollection([]Geometry{point3, polygon, lineString, multiPolygon})
+		So(gc.GetPolygons(), ShouldResemble, []*Polygon{polygon, polygon, polygon})
+		So(NewGeometryCollection([]Geometry{point3}).GetPolygons(), ShouldResemble, []*Polygon{})
+	})
+
+	Convey("For a geometryCollection without polygons, should be an empty PolygonI", t, func() {
+		var gc PolygonI = NewGeometryCollection([]Geometry{point3, lineString})
+		So(gc.GetPolygons(), ShouldBeEmpty)
+		area, err := Area(gc, Kilometers)
+		So(err, ShouldBeNil)
+		So(area, ShouldEqual, 0)
+		So(Union(gc), ShouldBeNil)
+		So(Union(gc, polygon).GetPolygons(), ShouldHaveLength, 1)
+	})
+
+	Convey("For nested geometryCollections, should return all points", t, func() {
+		inner := NewGeometryCollection([]Geometry{point1, point2})
+		gc := NewGeometryCollection([]Geometry{inner, point3})
+		So(gc.GetPoints(), ShouldResemble, []*Point{point1, point2, point3})
+	})
+}
+
+func TestType(t *testing.T) {
+	Convey("For built in geometries, should return geojson type name", t, func() {
+		So(NewPoint(0, 0).Type(), ShouldEqual, "Point")
+		So(NewMultiPoint(nil).Type(), ShouldEqual, "MultiPoint")
+		So(NewLineString(nil).Type(), ShouldEqual, "LineString")
+		So(NewMultiLineString(nil).Type(), ShouldEqual, "MultiLineString")
+		So(NewPolygon(nil).Type(), ShouldEqual, "Polygon")
+		So(NewMultiPolygon(nil).Type(), ShouldEqual, "MultiPolygon")
+		So(NewGeometryCollection(nil).Type(), ShouldEqual, "GeometryCollection")
+		So(NewFeature(nil, nil).Type(), ShouldEqual, "Feature")
+		So(NewFeatureCollection(nil).Type(), ShouldEqual, "FeatureCollection")
+	})
+}
+
+func TestBounds(t *testing.T) {
+	Convey("For a given lineString, should return its bounding box", t, func() {
+		lineString := NewLineString([]*Point{{35.4691, -97.522259}, {35.463455, -97.502754}})
+		So(lineString.Bounds(), ShouldResemble, NewBBox(-97.522259, 35.463455, -97.502754, 35.4691))
+	})
+
+	Convey("For an empty geometry, should return infinite bounding box", t, func() {
+		So(NewMultiPoint(nil).Bounds(), ShouldResemble, NewInfiniteBBox())
+	})
+}
+
+func TestCustomGeometry(t *testing.T) {
+	ring := NewLineString([]*Point{{0, 0}, {0, 10}, {10, 10}, {10, 0}, {0, 0}})
+	z := &zone{"depot", NewPolygon([]*LineString{ring})}
+	trace := &vehicleTrace{pings: []*Point{{1, 1}, {2, 4}, {12, 3}}}
+
+	Convey("Custom geometries should be accepted by measurement functions", t, func() {
+		So(Extent(trace), ShouldResemble, NewBBox(1, 1, 4, 12))
+		So(Extent(trace, z), ShouldResemble, NewBBox(0, 0, 10, 12))
+		So(Center(trace), ShouldResemble, NewPoint(6.5, 2.5))
+	})
+
+	Convey("Custom polygons should be accepted by joins", t, func() {
+		So(Inside(NewPoint(5, 5), z), ShouldBeTrue)
+		So(Within(trace.GetPoints(), []PolygonI{z}), ShouldResemble, []*Point{{1, 1}, {2, 4}})
+	})
 }
@@ -0,0 +1,383 @@
+package turfgo
+
+import (
+	"fmt"
+	"math"
+)
+
+// ProblemType is a kind of problem found by Validate
+type ProblemType int
+
+// ProblemType constants
+const (
+	// InvalidCoordinate is a coordinate which is not a finite number, or a latitude beyond the poles
+	InvalidCoordinate ProblemType = iota
+	// RepeatedPoint is a point equal to the one before it in a line or a ring
+	RepeatedPoint
+	// TooFewPoints is a line with less than 2 distinct points or a ring with less than 3
+	TooFewPoints
+	// UnclosedRing is a ring whose last point is not its first
+	UnclosedRing
+	// SelfIntersection is a ring crossing or touching itself, like a bow-tie
+	SelfIntersection
+	// RingIntersection is a ring crossing another ring of the same polygon, or sharing a part of its edges
+	RingIntersection
+	// HoleOutsideShell is a hole which is not inside the outer ring of its polygon
+	HoleOutsideShell
+	// NestedHoles is a hole inside another hole of the same polygon
+	NestedHoles
+	// OverlappingPolygons is a polygon of a multiPolygon overlapping another one
+	OverlappingPolygons
+)
+
+var problemNames = map[ProblemType]string{
+	InvalidCoordinate:   "invalid coordinate",
+	RepeatedPoint:       "repeated point",
+	TooFewPoints:        "too few points",
+	UnclosedRing:        "unclosed ring",
+	SelfIntersection:    "self intersection",
+	RingIntersection:    "ring intersection",
+	HoleOutsideShell:    "hole outside shell",
+	NestedHoles:         "nested holes",
+	OverlappingPolygons: "overlapping polygons",
+}
+
+func (t ProblemType) String() string {
+	return problemNames[t]
+}
+
+// Problem is a problem of a geometry found by Validate. Location is the point where the problem is. Path gives
+// the indexes of the part of the geometry with the problem, from the top: the member of a collection or the
+// feature of a featureCollection, then the polygon of a multiPolygon or the line of a multiLineString, then the
+// ring of a polygon, then the point of a line or a ring when the problem is at a point or a segment.
+type Problem struct {
+	Type     ProblemType
+	Location *Point
+	Path     []int
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%v at (%v, %v), path %v", p.Type, p.Location.Lat, p.Location.Lng, p.Path)
+}
+
+// Validate checks a geometry and returns its problems, it returns an empty slice for a valid geometry.
+// Coordinates are taken as planar to look for intersections, like Inside does. Lines may cross themselves,
+// rings of a polygon may touch each other at a point.
+func Validate(geometry Geometry) []Problem {
+	v := &validator{problems: []Problem{}}
+	v.validate(geometry, nil)
+	return v.problems
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) report(problemType ProblemType, location *Point, path ...int) {
+	v.problems = append(v.problems, Problem{problemType, location, path})
+}
+
+func (v *validator) validate(geometry Geometry, path []int) {
+	switch g := geometry.(type) {
+	case *Point:
+		if !isEmptyPoint(g) && !isValidCoordinate(g) {
+			v.report(InvalidCoordinate, g, path...)
+		}
+	case *MultiPoint:
+		for i, point := range g.Points {
+			v.validate(point, appendPath(path, i))
+		}
+	case *LineString:
+		v.validateLine(g, path)
+	case *MultiLineString:
+		for i, line := range g.LineStrings {
+			v.validateLine(line, appendPath(path, i))
+		}
+	case *Polygon:
+		v.validatePolygon(g, path)
+	case *MultiPolygon:
+		for i, polygon := range g.Polygons {
+			v.validatePolygon(polygon, appendPath(path, i))
+		}
+		v.validateOverlaps(g, path)
+	case *GeometryCollection:
+		for i, member := range g.Geometries {
+			v.validate(member, appendPath(path, i))
+		}
+	case *Feature:
+		if g != nil && g.Geometry != nil {
+			v.validate(g.Geometry, path)
+		}
+	case *FeatureCollection:
+		for i, feature := range g.Features {
+			v.validate(feature, appendPath(path, i))
+		}
+	}
+}
+
+// validatePoints reports invalid coordinates and repeated points, it returns the distinct valid points as a
+// planar ring or line with the index of every vertex in points
+func (v *validator) validatePoints(points []*Point, path []int) (vertices []vec, indexes []int) {
+	for i, point := range points {
+		if !isValidCoordinate(point) {
+			v.report(InvalidCoordinate, point, appendPath(path, i)...)
+			continue
+		}
+		vertex := pointToVec(point)
+		if len(vertices) > 0 && vertices[len(vertices)-1] == vertex {
+			v.report(RepeatedPoint, point, appendPath(path, i)...)
+			continue
+		}
+		vertices = append(vertices, vertex)
+		indexes = append(indexes, i)
+	}
+	return vertices, indexes
+}
+
+func (v *validator) validateLine(line *LineString, path []int) {
+	vertices, _ := v.validatePoints(line.Points, path)
+	if len(vertices) < 2 {
+		v.report(TooFewPoints, firstPoint(line.Points), path...)
+	}
+}
+
+// planarRing is a ring of a polygon being validated
+type planarRing struct {
+	vertices []vec
+	indexes  []int
+	ring     *LineString
+}
+
+func (v *validator) validatePolygon(polygon *Polygon, path []int) {
+	rings := []planarRing{}
+	ringIndexes := []int{}
+	for r, ring := range polygon.LineStrings {
+		ringPath := appendPath(path, r)
+		points := ring.Points
+		if len(points) > 1 {
+			if pointToVec(points[0]) != pointToVec(points[len(points)-1]) {
+				v.report(UnclosedRing, points[0], ringPath...)
+			} else {
+				// the closing point is not a repeated point
+				points = points[:len(points)-1]
+			}
+		}
+		vertices, indexes := v.validatePoints(points, ringPath)
+		if len(vertices) > 1 && vertices[0] == vertices[len(vertices)-1] {
+			vertices, indexes = vertices[:len(vertices)-1], indexes[:len(indexes)-1]
+		}
+		if len(vertices) < 3 {
+			v.report(TooFewPoints, firstPoint(ring.Points), ringPath...)
+			continue
+		}
+		rings = append(rings, planarRing{vertices, indexes, ring})
+		ringIndexes = append(ringIndexes, r)
+	}
+	v.validateIntersections(rings, ringIndexes, path)
+	v.validateHoles(rings, ringIndexes, path)
+}
+
+// validateIntersections looks for rings crossing themselves or each other. Segments of a ring must only meet
+// at the vertex shared by consecutive segments, segments of different rings must not cross or overlap.
+func (v *validator) validateIntersections(rings []planarRing, ringIndexes []int, path []int) {
+	parts := make([]part, len(rings))
+	for i, ring := range rings {
+		parts[i] = part{ring.vertices, true}
+	}
+	partIntersections(parts, func(ringA, _, ringB, segment int, points []vec, crossing bool) {
+		if ringA == ringB {
+			v.report(SelfIntersection, vecToPoint(points[0]), appendPath(path, ringIndexes[ringB], rings[ringB].indexes[segment])...)
+		} else if crossing || len(points) > 1 {
+			v.report(RingIntersection, vecToPoint(points[0]), appendPath(path, ringIndexes[ringB])...)
+		}
+	})
+}
+
+// validateHoles checks that the holes are inside the shell and outside each other, it tests a vertex of every
+// hole which is not on the other ring
+func (v *validator) validateHoles(rings []planarRing, ringIndexes []int, path []int) {
+	if len(rings) == 0 || ringIndexes[0] != 0 {
+		return
+	}
+	for h := 1; h < len(rings); h++ {
+		if p, ok := vertexOffRing(rings[h], rings[0]); ok && !inRing(vecToPoint(p), rings[0].ring) {
+			v.report(HoleOutsideShell, vecToPoint(p), appendPath(path, ringIndexes[h])...)
+		}
+		for o := 1; o < len(rings); o++ {
+			if o == h {
+				continue
+			}
+			if p, ok := vertexOffRing(rings[h], rings[o]); ok && inRing(vecToPoint(p), rings[o].ring) {
+				v.report(NestedHoles, vecToPoint(p), appendPath(path, ringIndexes[h])...)
+			}
+		}
+	}
+}
+
+// validateOverlaps reports the polygons of a multiPolygon whose interior overlaps the one of a previous polygon
+func (v *validator) validateOverlaps(multiPolygon *MultiPolygon, path []int) {
+	for j, polygon := range multiPolygon.Polygons {
+		for _, other := range multiPolygon.Polygons[:j] {
+			overlap, _ := DoesBboxOverlap(polygon.Bounds(), other.Bounds())
+			if !overlap {
+				continue
+			}
+			if intersection := Intersect(other, polygon); intersection != nil {
+				v.report(OverlappingPolygons, intersection.GetPoints()[0], appendPath(path, j)...)
+				break
+			}
+		}
+	}
+}
+
+// MakeValid returns a valid copy of a geometry, or the geometry itself when it is valid. Coordinates which are
+// not numbers and repeated points are removed, lines reduced to a point become points. Invalid polygons are
+// rebuilt from the areas their rings enclose with the even-odd rule, then merged: rings are closed, a bow-tie
+// becomes two polygons, a hole outside its shell becomes a polygon and overlapping polygons are merged, so a
+// Polygon may become a MultiPolygon. A hole of the rebuilt polygons which no shell encloses, which can only
+// happen when the shell is thinner than the tolerance of the overlay and is dropped as a sliver, is dropped too.
+// Parts with nothing left are dropped, nil is returned when nothing is left, like for a point which is not a
+// number. Latitudes beyond the poles are not repaired.
+func MakeValid(geometry Geometry) Geometry {
+	if len(Validate(geometry)) == 0 {
+		return geometry
+	}
+	switch g := geometry.(type) {
+	case *Point:
+		if !isFinite(g) {
+			return nil
+		}
+	case *MultiPoint:
+		points := []*Point{}
+		for _, point := range g.Points {
+			if isFinite(point) {
+				points = append(points, point)
+			}
+		}
+		return NewMultiPoint(points)
+	case *LineString:
+		points := cleanPoints(g.Points)
+		switch len(points) {
+		case 0:
+			return nil
+		case 1:
+			return points[0]
+		}
+		return NewLineString(points)
+	case *MultiLineString:
+		lineStrings := []*LineString{}
+		for _, line := range g.LineStrings {
+			if points := cleanPoints(line.Points); len(points) > 1 {
+				lineStrings = append(lineStrings, NewLineString(points))
+			}
+		}
+		if len(lineStrings) == 0 {
+			return nil
+		}
+		return NewMultiLineString(lineStrings)
+	case *Polygon, *MultiPolygon:
+		return makeValidPolygon(g.(PolygonI))
+	case *GeometryCollection:
+		geometries := []Geometry{}
+		for _, member := range g.Geometries {
+			if valid := MakeValid(member); valid != nil {
+				geometries = append(geometries, valid)
+			}
+		}
+		return NewGeometryCollection(geometries)
+	case *Feature:
+		if g == nil {
+			return g
+		}
+		feature := *g
+		if g.Geometry != nil {
+			feature.Geometry = MakeValid(g.Geometry)
+		}
+		return &feature
+	case *FeatureCollection:
+		features := make([]*Feature, len(g.Features))
+		for i, feature := range g.Features {
+			features[i] = MakeValid(feature).(*Feature)
+		}
+		return &FeatureCollection{Features: features, BoundingBox: g.BoundingBox}
+	}
+	return geometry
+}
+
+// makeValidPolygon overlays the rings of the polygons, a face of the overlay is kept if it is inside an odd
+// number of rings of any polygon
+func makeValidPolygon(polygon PolygonI) PolygonI {
+	cleaned := []*Polygon{}
+	for _, p := range polygon.GetPolygons() {
+		rings := []*LineString{}
+		for _, ring := range p.LineStrings {
+			rings = append(rings, NewLineString(cleanPoints(ring.Points)))
+		}
+		cleaned = append(cleaned, NewPolygon(rings))
+	}
+	rings, owners := planarRings(NewMultiPolygon(cleaned))
+	if len(rings) == 0 {
+		return nil
+	}
+	edges := [][2]vec{}
+	bounds := rect{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
+	for _, ring := range rings {
+		for k, v := range ring {
+			edges = append(edges, [2]vec{v, ring[(k+1)%len(ring)]})
+		}
+		bounds = bounds.extend(ringRect(ring))
+	}
+	return planarPolygons(overlay(edges, newRingIndex(rings, owners).contains, planarTolerance(bounds)), vecToPoint)
+}
+
+// cleanPoints returns the points without the ones which are not finite numbers and without repeated points
+func cleanPoints(points []*Point) []*Point {
+	cleaned := []*Point{}
+	for _, point := range points {
+		if !isFinite(point) {
+			continue
+		}
+		if len(cleaned) > 0 && pointToVec(cleaned[len(cleaned)-1]) == pointToVec(point) {
+			continue
+		}
+		cleaned = append(cleaned, point)
+	}
+	return cleaned
+}
+
+// vertexOffRing returns a vertex of a ring which is not on the edges of another
+func vertexOffRing(ring, other planarRing) (vec, bool) {
+	for _, p := range ring.vertices {
+		on := false
+		for i, a := range other.vertices {
+			if _, ok := onSegment(p, a, other.vertices[(i+1)%len(other.vertices)], 0); ok {
+				on = true
+				break
+			}
+		}
+		if !on {
+			return p, true
+		}
+	}
+	return vec{}, false
+}
+
+func isValidCoordinate(point *Point) bool {
+	return isFinite(point) && math.Abs(point.Lat) <= 90
+}
+
+func isFinite(point *Point) bool {
+	return !math.IsNaN(point.Lat) && !math.IsNaN(point.Lng) && !math.IsInf(point.Lat, 0) && !math.IsInf(point.Lng, 0)
+}
+
+func firstPoint(points []*Point) *Point {
+	if len(points) == 0 {
+		return emptyPoint()
+	}
+	return points[0]
+}
+
+// appendPath returns a new path with the given indexes added
+func appendPath(path []int, indexes ...int) []int {
+	return append(append([]int{}, path...), indexes...)
+}
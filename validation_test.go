@@ -0,0 +1,145 @@
+package turfgo
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func problemTypes(problems []Problem) []ProblemType {
+	types := []ProblemType{}
+	for _, problem := range problems {
+		types = append(types, problem.Type)
+	}
+	return types
+}
+
+func TestValidate(t *testing.T) {
+	Convey("Given valid geometries, should return no problem", t, func() {
+		withHole := NewPolygon([]*LineString{box(0, 0, 10, 10).LineStrings[0], box(2, 2, 4, 4).LineStrings[0],
+			xyLine(6, 6, 8, 6, 6, 8, 6, 6)})
+		So(Validate(withHole), ShouldBeEmpty)
+		So(Validate(NewMultiPolygon([]*Polygon{box(0, 0, 1, 1), box(1, 1, 2, 2)})), ShouldBeEmpty)
+		So(Validate(xyLine(0, 0, 10, 10, 10, 0, 0, 10)), ShouldBeEmpty)
+		So(Validate(NewPoint(math.NaN(), math.NaN())), ShouldBeEmpty)
+		So(Validate(longRoute), ShouldBeEmpty)
+	})
+
+	Convey("Given rings with bad points, should report them with their path", t, func() {
+		unclosed := NewPolygon([]*LineString{xyLine(0, 0, 10, 0, 10, 10, 0, 10)})
+		So(Validate(unclosed), ShouldResemble, []Problem{{UnclosedRing, xyPoint(0, 0), []int{0}}})
+
+		repeated := NewPolygon([]*LineString{xyLine(0, 0, 10, 0, 10, 0, 10, 10, 0, 0)})
+		So(Validate(repeated), ShouldResemble, []Problem{{RepeatedPoint, xyPoint(10, 0), []int{0, 2}}})
+
+		flat := NewPolygon([]*LineString{box(0, 0, 10, 10).LineStrings[0], xyLine(1, 1, 2, 2, 1, 1)})
+		So(Validate(flat), ShouldResemble, []Problem{{TooFewPoints, xyPoint(1, 1), []int{1}}})
+
+		invalid := NewPolygon([]*LineString{xyLine(0, 0, 10, 0, 10, 100, 0, 10, 0, 0)})
+		So(problemTypes(Validate(invalid)), ShouldResemble, []ProblemType{InvalidCoordinate})
+	})
+
+	Convey("Given rings crossing themselves or each other, should report where", t, func() {
+		bowTie := NewPolygon([]*LineString{xyLine(0, 0, 10, 10, 10, 0, 0, 10, 0, 0)})
+		So(Validate(bowTie), ShouldResemble, []Problem{{SelfIntersection, xyPoint(5, 5), []int{0, 2}}})
+
+		touching := NewPolygon([]*LineString{xyLine(0, 0, 10, 0, 5, 5, 10, 10, 0, 10, 5, 5, 0, 0)})
+		So(problemTypes(Validate(touching)), ShouldContain, SelfIntersection)
+
+		spike := NewPolygon([]*LineString{xyLine(0, 0, 10, 0, 10, 10, 5, 10, 5, 15, 5, 10, 0, 10, 0, 0)})
+		problems := Validate(spike)
+		So(problems, ShouldContain, Problem{SelfIntersection, xyPoint(5, 10), []int{0, 4}})
+
+		crossing := box(0, 0, 10, 10, [4]float64{5, 5, 15, 8})
+		So(Validate(crossing), ShouldContain, Problem{RingIntersection, xyPoint(10, 5), []int{1}})
+	})
+
+	Convey("Given misplaced holes, should report them", t, func() {
+		outside := box(0, 0, 10, 10, [4]float64{20, 20, 30, 30})
+		So(Validate(outside), ShouldResemble, []Problem{{HoleOutsideShell, xyPoint(20, 20), []int{1}}})
+
+		nested := box(0, 0, 10, 10, [4]float64{1, 1, 9, 9}, [4]float64{2, 2, 3, 3})
+		So(Validate(nested), ShouldResemble, []Problem{{NestedHoles, xyPoint(2, 2), []int{2}}})
+	})
+
+	Convey("Given a multiPolygon with overlapping polygons, should report the second one", t, func() {
+		overlapping := NewMultiPolygon([]*Polygon{box(0, 0, 10, 10), box(20, 20, 30, 30), box(5, 5, 15, 15)})
+		problems := Validate(overlapping)
+		So(problemTypes(problems), ShouldResemble, []ProblemType{OverlappingPolygons})
+		So(problems[0].Path, ShouldResemble, []int{2})
+	})
+
+	Convey("Given lines and collections, should give the path of the problems", t, func() {
+		line := NewLineString([]*Point{{0, 0}, {math.NaN(), 1}, {0, 0}})
+		collection := NewGeometryCollection([]Geometry{NewPoint(0, 0), NewMultiLineString([]*LineString{xyLine(0, 0, 1, 1), line})})
+		features := NewFeatureCollection([]*Feature{NewFeature(nil, nil), NewFeature(collection, nil)})
+		So(Validate(features), ShouldResemble, []Problem{
+			{InvalidCoordinate, line.Points[1], []int{1, 1, 1, 1}},
+			{RepeatedPoint, line.Points[2], []int{1, 1, 1, 2}},
+			{TooFewPoints, line.Points[0], []int{1, 1, 1}},
+		})
+		So(Validate(NewMultiPoint([]*Point{{0, 0}, {91, 0}})), ShouldResemble, []Problem{{InvalidCoordinate, &Point{91, 0}, []int{1}}})
+	})
+
+	Convey("Given a featureCollection with nil features, should skip them", t, func() {
+		line := NewLineString([]*Point{{0, 0}, {0, 0}, {1, 1}})
+		features := NewFeatureCollection([]*Feature{nil, NewFeature(line, nil)})
+		So(Validate(features), ShouldResemble, []Problem{{RepeatedPoint, line.Points[1], []int{1, 1}}})
+	})
+
+	Convey("Given a problem, should describe it", t, func() {
+		So(Problem{SelfIntersection, xyPoint(5, 5), []int{0, 2}}.String(), ShouldEqual, "self intersection at (5, 5), path [0 2]")
+	})
+}
+
+func TestMakeValid(t *testing.T) {
+	Convey("Given a valid geometry, should return it", t, func() {
+		polygon := box(0, 0, 10, 10)
+		So(MakeValid(polygon), ShouldEqual, polygon)
+	})
+
+	Convey("Given invalid polygons, should repair them", t, func() {
+		unclosed := NewPolygon([]*LineString{xyLine(0, 0, 10, 0, 10, 0, 10, 10, 0, 10)})
+		valid := MakeValid(unclosed).(*Polygon)
+		So(Validate(valid), ShouldBeEmpty)
+		So(planarArea(valid), ShouldAlmostEqual, 100)
+
+		bowTie := NewPolygon([]*LineString{xyLine(0, 0, 10, 10, 10, 0, 0, 10, 0, 0)})
+		repaired := MakeValid(bowTie).(*MultiPolygon)
+		So(len(repaired.Polygons), ShouldEqual, 2)
+		So(Validate(repaired), ShouldBeEmpty)
+		So(planarArea(repaired), ShouldAlmostEqual, 50)
+
+		outside := box(0, 0, 10, 10, [4]float64{20, 20, 30, 30})
+		So(planarArea(MakeValid(outside).(*MultiPolygon)), ShouldAlmostEqual, 200)
+
+		overlapping := NewMultiPolygon([]*Polygon{box(0, 0, 10, 10), box(5, 5, 15, 15)})
+		merged := MakeValid(overlapping).(*Polygon)
+		So(Validate(merged), ShouldBeEmpty)
+		So(planarArea(merged), ShouldAlmostEqual, 175)
+
+		So(MakeValid(NewPolygon([]*LineString{xyLine(0, 0, 1, 1, 0, 0)})), ShouldBeNil)
+	})
+
+	Convey("Given invalid lines and collections, should repair their parts", t, func() {
+		line := NewLineString([]*Point{{0, 0}, {0, 0}, {math.NaN(), 0}, {1, 1}})
+		So(MakeValid(line), ShouldResemble, NewLineString([]*Point{{0, 0}, {1, 1}}))
+		So(MakeValid(NewLineString([]*Point{{0, 0}, {0, 0}})), ShouldResemble, &Point{0, 0})
+		So(MakeValid(NewLineString([]*Point{{math.Inf(1), 0}})), ShouldBeNil)
+		So(MakeValid(NewMultiLineString([]*LineString{NewLineString([]*Point{{1, 1}, {1, 1}})})), ShouldBeNil)
+
+		feature := NewFeature(NewGeometryCollection([]Geometry{NewLineString([]*Point{{1, 1}}), line}), map[string]interface{}{"a": 1})
+		valid := MakeValid(NewFeatureCollection([]*Feature{feature})).(*FeatureCollection)
+		So(Validate(valid), ShouldBeEmpty)
+		So(valid.Features[0].Properties, ShouldResemble, feature.Properties)
+		So(valid.Features[0].Geometry.(*GeometryCollection).Geometries, ShouldHaveLength, 2)
+	})
+
+	Convey("Given a featureCollection with nil features, should keep them nil", t, func() {
+		line := NewLineString([]*Point{{0, 0}, {0, 0}, {1, 1}})
+		valid := MakeValid(NewFeatureCollection([]*Feature{nil, NewFeature(line, nil)})).(*FeatureCollection)
+		So(valid.Features[0], ShouldBeNil)
+		So(valid.Features[1].Geometry, ShouldResemble, NewLineString([]*Point{{0, 0}, {1, 1}}))
+	})
+}
@@ -0,0 +1,400 @@
+package turfgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// WKB geometry type codes
+const (
+	wkbPoint              uint32 = 1
+	wkbLineString         uint32 = 2
+	wkbPolygon            uint32 = 3
+	wkbMultiPoint         uint32 = 4
+	wkbMultiLineString    uint32 = 5
+	wkbMultiPolygon       uint32 = 6
+	wkbGeometryCollection uint32 = 7
+)
+
+// EWKB flags set on the geometry type code
+const (
+	ewkbZ    uint32 = 0x80000000
+	ewkbM    uint32 = 0x40000000
+	ewkbSRID uint32 = 0x20000000
+)
+
+const (
+	wkbXDR byte = 0 // big endian
+	wkbNDR byte = 1 // little endian
+)
+
+var errWKBShort = errors.New("wkb: unexpected end of data")
+
+// MarshalWKB returns the well known binary representation of a geometry in the given byte order,
+// binary.LittleEndian or binary.BigEndian. An empty point is written with NaN coordinates.
+// A feature is written as its geometry and a featureCollection as a GeometryCollection.
+func MarshalWKB(geometry Geometry, byteOrder binary.ByteOrder) ([]byte, error) {
+	return MarshalEWKB(geometry, byteOrder, 0)
+}
+
+// MarshalEWKB returns the extended well known binary representation of a geometry, as used by PostGIS,
+// in the given byte order. The srid is written only when it is not 0, otherwise the result is plain WKB.
+func MarshalEWKB(geometry Geometry, byteOrder binary.ByteOrder, srid int) ([]byte, error) {
+	w := &wkbWriter{order: byteOrder}
+	if byteOrder == binary.BigEndian {
+		w.orderFlag = wkbXDR
+	} else if byteOrder == binary.LittleEndian {
+		w.orderFlag = wkbNDR
+	} else {
+		return nil, errors.New("wkb: byte order should be binary.LittleEndian or binary.BigEndian")
+	}
+	if srid < 0 || int64(srid) > math.MaxUint32 {
+		return nil, fmt.Errorf("wkb: invalid srid %d", srid)
+	}
+	err := w.writeGeometry(geometry, uint32(srid))
+	if err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
+
+// UnmarshalWKB parses a well known binary geometry in either byte order into a *Point, *MultiPoint,
+// *LineString, *MultiLineString, *Polygon, *MultiPolygon or *GeometryCollection. ISO and EWKB Z, M and ZM
+// geometries are accepted but only the x (lng) and y (lat) ordinates are kept. An EWKB srid is ignored.
+func UnmarshalWKB(wkb []byte) (Geometry, error) {
+	g, _, err := UnmarshalEWKB(wkb)
+	return g, err
+}
+
+// UnmarshalEWKB parses an extended well known binary geometry and returns it along with its srid,
+// which is 0 if the geometry has none. Plain WKB is accepted as well.
+func UnmarshalEWKB(ewkb []byte) (Geometry, int, error) {
+	r := &wkbReader{data: ewkb}
+	g, srid, err := r.readGeometry()
+	if err != nil {
+		return nil, 0, err
+	}
+	if r.pos != len(r.data) {
+		return nil, 0, fmt.Errorf("wkb: %d unexpected bytes after geometry", len(r.data)-r.pos)
+	}
+	return g, int(srid), nil
+}
+
+type wkbWriter struct {
+	buf       bytes.Buffer
+	order     binary.ByteOrder
+	orderFlag byte
+	scratch   [8]byte
+}
+
+func (w *wkbWriter) writeUint32(v uint32) {
+	w.order.PutUint32(w.scratch[:4], v)
+	w.buf.Write(w.scratch[:4])
+}
+
+func (w *wkbWriter) writeFloat64(v float64) {
+	w.order.PutUint64(w.scratch[:], math.Float64bits(v))
+	w.buf.Write(w.scratch[:])
+}
+
+func (w *wkbWriter) writeHeader(geometryType uint32, srid uint32) {
+	w.buf.WriteByte(w.orderFlag)
+	if srid != 0 {
+		w.writeUint32(geometryType | ewkbSRID)
+		w.writeUint32(srid)
+		return
+	}
+	w.writeUint32(geometryType)
+}
+
+func (w *wkbWriter) writePoints(points []*Point) {
+	w.writeUint32(uint32(len(points)))
+	for _, point := range points {
+		w.writeFloat64(point.Lng)
+		w.writeFloat64(point.Lat)
+	}
+}
+
+func (w *wkbWriter) writeRings(lineStrings []*LineString) {
+	w.writeUint32(uint32(len(lineStrings)))
+	for _, lineString := range lineStrings {
+		w.writePoints(lineString.Points)
+	}
+}
+
+// writeGeometry writes the srid only on the outer geometry, nested geometries never carry one.
+func (w *wkbWriter) writeGeometry(geometry Geometry, srid uint32) error {
+	switch g := geometry.(type) {
+	case *Point:
+		w.writeHeader(wkbPoint, srid)
+		w.writeFloat64(g.Lng)
+		w.writeFloat64(g.Lat)
+	case *LineString:
+		w.writeHeader(wkbLineString, srid)
+		w.writePoints(g.Points)
+	case *Polygon:
+		w.writeHeader(wkbPolygon, srid)
+		w.writeRings(g.LineStrings)
+	case *MultiPoint:
+		w.writeHeader(wkbMultiPoint, srid)
+		w.writeUint32(uint32(len(g.Points)))
+		for _, point := range g.Points {
+			w.writeGeometry(point, 0)
+		}
+	case *MultiLineString:
+		w.writeHeader(wkbMultiLineString, srid)
+		w.writeUint32(uint32(len(g.LineStrings)))
+		for _, lineString := range g.LineStrings {
+			w.writeGeometry(lineString, 0)
+		}
+	case *MultiPolygon:
+		w.writeHeader(wkbMultiPolygon, srid)
+		w.writeUint32(uint32(len(g.Polygons)))
+		for _, polygon := range g.Polygons {
+			w.writeGeometry(polygon, 0)
+		}
+	case *GeometryCollection:
+		return w.writeGeometryCollection(g.Geometries, srid)
+	case *Feature:
+		if g.Geometry == nil {
+			return errors.New("wkb: feature has no geometry")
+		}
+		return w.writeGeometry(g.Geometry, srid)
+	case *FeatureCollection:
+		geometries := []Geometry{}
+		for _, feature := range g.Features {
+			geometries = append(geometries, feature)
+		}
+		return w.writeGeometryCollection(geometries, srid)
+	default:
+		return fmt.Errorf("wkb: unsupported geometry type %T", geometry)
+	}
+	return nil
+}
+
+func (w *wkbWriter) writeGeometryCollection(geometries []Geometry, srid uint32) error {
+	w.writeHeader(wkbGeometryCollection, srid)
+	w.writeUint32(uint32(len(geometries)))
+	for _, geometry := range geometries {
+		if err := w.writeGeometry(geometry, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+	// dimension is the number of ordinates of each position of the geometry being read
+	dimension int
+}
+
+func (r *wkbReader) readByte() (byte, error) {
+	if r.pos+1 > len(r.data) {
+		return 0, errWKBShort
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, errWKBShort
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// readCount reads a number of elements, each of which takes at least minSize bytes
+func (r *wkbReader) readCount(minSize int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minSize) > uint64(len(r.data)-r.pos) {
+		return 0, errWKBShort
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readPosition() (*Point, error) {
+	if r.pos+8*r.dimension > len(r.data) {
+		return nil, errWKBShort
+	}
+	lng := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	lat := math.Float64frombits(r.order.Uint64(r.data[r.pos+8:]))
+	r.pos += 8 * r.dimension
+	return &Point{lat, lng}, nil
+}
+
+func (r *wkbReader) readPoints() ([]*Point, error) {
+	n, err := r.readCount(8 * r.dimension)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]*Point, 0, n)
+	for i := 0; i < n; i++ {
+		point, err := r.readPosition()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readRings() ([]*LineString, error) {
+	n, err := r.readCount(4)
+	if err != nil {
+		return nil, err
+	}
+	lineStrings := make([]*LineString, 0, n)
+	for i := 0; i < n; i++ {
+		points, err := r.readPoints()
+		if err != nil {
+			return nil, err
+		}
+		lineStrings = append(lineStrings, NewLineString(points))
+	}
+	return lineStrings, nil
+}
+
+// readHeader reads the byte order, geometry type, dimension and optional srid of a geometry
+func (r *wkbReader) readHeader() (uint32, uint32, error) {
+	orderFlag, err := r.readByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	switch orderFlag {
+	case wkbXDR:
+		r.order = binary.BigEndian
+	case wkbNDR:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, fmt.Errorf("wkb: invalid byte order %d", orderFlag)
+	}
+	geometryType, err := r.readUint32()
+	if err != nil {
+		return 0, 0, err
+	}
+	var srid uint32
+	if geometryType&ewkbSRID != 0 {
+		srid, err = r.readUint32()
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	r.dimension = 2
+	if geometryType&ewkbZ != 0 {
+		r.dimension++
+	}
+	if geometryType&ewkbM != 0 {
+		r.dimension++
+	}
+	geometryType &^= ewkbZ | ewkbM | ewkbSRID
+	switch geometryType / 1000 {
+	case 1, 2:
+		r.dimension++
+	case 3:
+		r.dimension += 2
+	}
+	return geometryType % 1000, srid, nil
+}
+
+func (r *wkbReader) readGeometry() (Geometry, uint32, error) {
+	geometryType, srid, err := r.readHeader()
+	if err != nil {
+		return nil, 0, err
+	}
+	switch geometryType {
+	case wkbPoint:
+		point, err := r.readPosition()
+		if err != nil {
+			return nil, 0, err
+		}
+		return point, srid, nil
+	case wkbLineString:
+		points, err := r.readPoints()
+		if err != nil {
+			return nil, 0, err
+		}
+		return NewLineString(points), srid, nil
+	case wkbPolygon:
+		lineStrings, err := r.readRings()
+		if err != nil {
+			return nil, 0, err
+		}
+		return NewPolygon(lineStrings), srid, nil
+	case wkbMultiPoint, wkbMultiLineString, wkbMultiPolygon, wkbGeometryCollection:
+		geometries, err := r.readGeometries()
+		if err != nil {
+			return nil, 0, err
+		}
+		g, err := newWKBCollection(geometryType, geometries)
+		if err != nil {
+			return nil, 0, err
+		}
+		return g, srid, nil
+	}
+	return nil, 0, fmt.Errorf("wkb: unknown geometry type %d", geometryType)
+}
+
+func (r *wkbReader) readGeometries() ([]Geometry, error) {
+	// the smallest geometry is a header followed by an element count
+	n, err := r.readCount(9)
+	if err != nil {
+		return nil, err
+	}
+	geometries := make([]Geometry, 0, n)
+	for i := 0; i < n; i++ {
+		geometry, _, err := r.readGeometry()
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, geometry)
+	}
+	return geometries, nil
+}
+
+func newWKBCollection(geometryType uint32, geometries []Geometry) (Geometry, error) {
+	switch geometryType {
+	case wkbMultiPoint:
+		points := []*Point{}
+		for _, geometry := range geometries {
+			point, ok := geometry.(*Point)
+			if !ok {
+				return nil, errors.New("wkb: multiPoint should only hold points")
+			}
+			points = append(points, point)
+		}
+		return NewMultiPoint(points), nil
+	case wkbMultiLineString:
+		lineStrings := []*LineString{}
+		for _, geometry := range geometries {
+			lineString, ok := geometry.(*LineString)
+			if !ok {
+				return nil, errors.New("wkb: multiLineString should only hold lineStrings")
+			}
+			lineStrings = append(lineStrings, lineString)
+		}
+		return NewMultiLineString(lineStrings), nil
+	case wkbMultiPolygon:
+		polygons := []*Polygon{}
+		for _, geometry := range geometries {
+			polygon, ok := geometry.(*Polygon)
+			if !ok {
+				return nil, errors.New("wkb: multiPolygon should only hold polygons")
+			}
+			polygons = append(polygons, polygon)
+		}
+		return NewMultiPolygon(polygons), nil
+	}
+	return NewGeometryCollection(geometries), nil
+}
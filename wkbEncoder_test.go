@@ -0,0 +1,130 @@
+package turfgo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMarshalWKB(t *testing.T) {
+	Convey("Given a point, should write wkb in both byte orders", t, func() {
+		wkb, err := MarshalWKB(NewPoint(2, 1), binary.LittleEndian)
+		So(err, ShouldBeNil)
+		So(strings.ToUpper(hex.EncodeToString(wkb)), ShouldEqual, "0101000000000000000000F03F0000000000000040")
+
+		wkb, err = MarshalWKB(NewPoint(2, 1), binary.BigEndian)
+		So(err, ShouldBeNil)
+		So(strings.ToUpper(hex.EncodeToString(wkb)), ShouldEqual, "00000000013FF00000000000004000000000000000")
+	})
+
+	Convey("Given a point and srid, should write ewkb", t, func() {
+		ewkb, err := MarshalEWKB(NewPoint(2, 1), binary.LittleEndian, 4326)
+		So(err, ShouldBeNil)
+		So(strings.ToUpper(hex.EncodeToString(ewkb)), ShouldEqual, "0101000020E6100000000000000000F03F0000000000000040")
+	})
+
+	Convey("Given a lineString, should write wkb", t, func() {
+		ls := NewLineString([]*Point{{2, 1}, {4, 3}})
+		wkb, err := MarshalWKB(ls, binary.LittleEndian)
+		So(err, ShouldBeNil)
+		So(strings.ToUpper(hex.EncodeToString(wkb)), ShouldEqual,
+			"010200000002000000000000000000F03F000000000000004000000000000008400000000000001040")
+	})
+
+	Convey("Given wkt fixtures, should read back the geometries written in both byte orders with and without srid", t, func() {
+		files, _ := ioutil.ReadDir("./testdata/wkt")
+		for _, f := range files {
+			j, _ := ioutil.ReadFile("./testdata/wkt/" + f.Name())
+			g, _ := ParseWKT(string(j))
+			for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+				for _, srid := range []int{0, 4326} {
+					ewkb, err := MarshalEWKB(g, order, srid)
+					So(err, ShouldBeNil)
+					result, resultSrid, err := UnmarshalEWKB(ewkb)
+					So(err, ShouldBeNil)
+					So(resultSrid, ShouldEqual, srid)
+					expected, _ := MarshalWKT(g)
+					actual, _ := MarshalWKT(result)
+					So(actual, ShouldEqual, expected)
+				}
+			}
+		}
+	})
+
+	Convey("Given a feature, should write its geometry", t, func() {
+		wkb, err := MarshalWKB(NewFeature(NewPoint(2, 1), nil), binary.LittleEndian)
+		So(err, ShouldBeNil)
+		So(strings.ToUpper(hex.EncodeToString(wkb)), ShouldEqual, "0101000000000000000000F03F0000000000000040")
+	})
+
+	Convey("Given invalid arguments, should return error", t, func() {
+		_, err := MarshalEWKB(NewPoint(2, 1), binary.LittleEndian, -1)
+		So(err.Error(), ShouldEqual, "wkb: invalid srid -1")
+		_, err = MarshalWKB(NewFeature(nil, nil), binary.LittleEndian)
+		So(err.Error(), ShouldEqual, "wkb: feature has no geometry")
+	})
+}
+
+func TestUnmarshalWKB(t *testing.T) {
+	decode := func(h string) []byte {
+		b, _ := hex.DecodeString(h)
+		return b
+	}
+
+	Convey("Given wkb, should read geometry", t, func() {
+		g, err := UnmarshalWKB(decode("00000000013FF00000000000004000000000000000"))
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, NewPoint(2, 1))
+	})
+
+	Convey("Given ewkb with srid and Z, should read srid and drop Z", t, func() {
+		g, srid, err := UnmarshalEWKB(decode("01010000A0E6100000000000000000F03F00000000000000400000000000000840"))
+		So(err, ShouldBeNil)
+		So(srid, ShouldEqual, 4326)
+		So(g, ShouldResemble, NewPoint(2, 1))
+	})
+
+	Convey("Given ISO wkb with Z, M and ZM, should drop extra ordinates", t, func() {
+		g, err := UnmarshalWKB(decode("01E9030000000000000000F03F00000000000000400000000000000840"))
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, NewPoint(2, 1))
+
+		g, err = UnmarshalWKB(decode("01D2070000010000000000000000000000000000000000F03F0000000000000040"))
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, NewLineString([]*Point{{1, 0}}))
+
+		g, err = UnmarshalWKB(decode("01B90B0000000000000000F03F000000000000004000000000000008400000000000001040"))
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, NewPoint(2, 1))
+	})
+
+	Convey("Given wkb of an empty point, should read NaN coordinates", t, func() {
+		g, err := UnmarshalWKB(decode("0101000000000000000000F87F000000000000F87F"))
+		So(err, ShouldBeNil)
+		So(math.IsNaN(g.(*Point).Lat), ShouldBeTrue)
+	})
+
+	Convey("Given invalid wkb, should return error", t, func() {
+		errorCases := map[string]string{
+			"":                                       "wkb: unexpected end of data",
+			"0201000000":                             "wkb: invalid byte order 2",
+			"0108000000":                             "wkb: unknown geometry type 8",
+			"0101000000000000000000F03F":             "wkb: unexpected end of data",
+			"0102000000FFFFFFFF":                     "wkb: unexpected end of data",
+			"01040000000100000001020000000000000000": "wkb: multiPoint should only hold points",
+			"0101000000000000000000F03F000000000000004000": "wkb: 1 unexpected bytes after geometry",
+		}
+		for h, message := range errorCases {
+			b, err := hex.DecodeString(h)
+			if err == nil {
+				_, err = UnmarshalWKB(b)
+			}
+			So(err.Error(), ShouldEqual, message)
+		}
+	})
+}
@@ -0,0 +1,471 @@
+package turfgo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WKT geometry tags
+const (
+	wktPoint              = "POINT"
+	wktMultiPoint         = "MULTIPOINT"
+	wktLineString         = "LINESTRING"
+	wktMultiLineString    = "MULTILINESTRING"
+	wktPolygon            = "POLYGON"
+	wktMultiPolygon       = "MULTIPOLYGON"
+	wktGeometryCollection = "GEOMETRYCOLLECTION"
+	wktEmpty              = "EMPTY"
+)
+
+// ParseWKT parses a well known text representation of a geometry into a *Point, *MultiPoint,
+// *LineString, *MultiLineString, *Polygon, *MultiPolygon or *GeometryCollection.
+// Z, M and ZM geometries are accepted but only the x (lng) and y (lat) ordinates are kept.
+// POINT EMPTY is parsed into a point with NaN coordinates, other EMPTY geometries have no points.
+// An EWKT SRID prefix like "SRID=4326;" is accepted and ignored.
+func ParseWKT(wkt string) (Geometry, error) {
+	if strings.HasPrefix(strings.ToUpper(wkt), "SRID=") {
+		i := strings.Index(wkt, ";")
+		if i < 0 {
+			return nil, errors.New("wkt: missing ';' after SRID")
+		}
+		wkt = wkt[i+1:]
+	}
+	tokens, err := tokenizeWKT(wkt)
+	if err != nil {
+		return nil, err
+	}
+	p := &wktParser{tokens: tokens}
+	g, err := p.parseGeometry()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("wkt: unexpected token %q after geometry", p.peek())
+	}
+	return g, nil
+}
+
+// MarshalWKT returns the well known text representation of a geometry in [lng lat] order.
+// A feature is written as its geometry and a featureCollection as a GEOMETRYCOLLECTION.
+func MarshalWKT(geometry Geometry) (string, error) {
+	var buf bytes.Buffer
+	err := writeWKT(&buf, geometry)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func writeWKT(buf *bytes.Buffer, geometry Geometry) error {
+	switch g := geometry.(type) {
+	case *Point:
+		buf.WriteString(wktPoint)
+		if isEmptyPoint(g) {
+			buf.WriteString(" " + wktEmpty)
+			return nil
+		}
+		buf.WriteString(" (")
+		writeWKTPosition(buf, g)
+		buf.WriteString(")")
+	case *MultiPoint:
+		buf.WriteString(wktMultiPoint)
+		if len(g.Points) == 0 {
+			buf.WriteString(" " + wktEmpty)
+			return nil
+		}
+		buf.WriteString(" (")
+		for i, point := range g.Points {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if isEmptyPoint(point) {
+				buf.WriteString(wktEmpty)
+				continue
+			}
+			buf.WriteString("(")
+			writeWKTPosition(buf, point)
+			buf.WriteString(")")
+		}
+		buf.WriteString(")")
+	case *LineString:
+		buf.WriteString(wktLineString + " ")
+		writeWKTLineString(buf, g)
+	case *MultiLineString:
+		buf.WriteString(wktMultiLineString + " ")
+		writeWKTLineStrings(buf, g.LineStrings)
+	case *Polygon:
+		buf.WriteString(wktPolygon + " ")
+		writeWKTLineStrings(buf, g.LineStrings)
+	case *MultiPolygon:
+		buf.WriteString(wktMultiPolygon)
+		if len(g.Polygons) == 0 {
+			buf.WriteString(" " + wktEmpty)
+			return nil
+		}
+		buf.WriteString(" (")
+		for i, polygon := range g.Polygons {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			writeWKTLineStrings(buf, polygon.LineStrings)
+		}
+		buf.WriteString(")")
+	case *GeometryCollection:
+		return writeWKTGeometryCollection(buf, g.Geometries)
+	case *Feature:
+		if g.Geometry == nil {
+			return errors.New("wkt: feature has no geometry")
+		}
+		return writeWKT(buf, g.Geometry)
+	case *FeatureCollection:
+		geometries := []Geometry{}
+		for _, feature := range g.Features {
+			geometries = append(geometries, feature)
+		}
+		return writeWKTGeometryCollection(buf, geometries)
+	default:
+		return fmt.Errorf("wkt: unsupported geometry type %T", geometry)
+	}
+	return nil
+}
+
+func writeWKTGeometryCollection(buf *bytes.Buffer, geometries []Geometry) error {
+	buf.WriteString(wktGeometryCollection)
+	if len(geometries) == 0 {
+		buf.WriteString(" " + wktEmpty)
+		return nil
+	}
+	buf.WriteString(" (")
+	for i, geometry := range geometries {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if err := writeWKT(buf, geometry); err != nil {
+			return err
+		}
+	}
+	buf.WriteString(")")
+	return nil
+}
+
+func writeWKTPosition(buf *bytes.Buffer, point *Point) {
+	buf.WriteString(strconv.FormatFloat(point.Lng, 'f', -1, 64))
+	buf.WriteString(" ")
+	buf.WriteString(strconv.FormatFloat(point.Lat, 'f', -1, 64))
+}
+
+func writeWKTLineString(buf *bytes.Buffer, lineString *LineString) {
+	if len(lineString.Points) == 0 {
+		buf.WriteString(wktEmpty)
+		return
+	}
+	buf.WriteString("(")
+	for i, point := range lineString.Points {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		writeWKTPosition(buf, point)
+	}
+	buf.WriteString(")")
+}
+
+func writeWKTLineStrings(buf *bytes.Buffer, lineStrings []*LineString) {
+	if len(lineStrings) == 0 {
+		buf.WriteString(wktEmpty)
+		return
+	}
+	buf.WriteString("(")
+	for i, lineString := range lineStrings {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		writeWKTLineString(buf, lineString)
+	}
+	buf.WriteString(")")
+}
+
+func isWKTTag(tag string) bool {
+	switch tag {
+	case wktPoint, wktMultiPoint, wktLineString, wktMultiLineString, wktPolygon, wktMultiPolygon, wktGeometryCollection:
+		return true
+	}
+	return false
+}
+
+func tokenizeWKT(wkt string) ([]string, error) {
+	tokens := []string{}
+	for i := 0; i < len(wkt); {
+		c := wkt[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, string(c))
+			i++
+		case isWKTLetter(c):
+			start := i
+			for i < len(wkt) && isWKTLetter(wkt[i]) {
+				i++
+			}
+			tokens = append(tokens, strings.ToUpper(wkt[start:i]))
+		case isWKTNumber(c):
+			start := i
+			for i < len(wkt) && isWKTNumber(wkt[i]) {
+				i++
+			}
+			tokens = append(tokens, wkt[start:i])
+		default:
+			return nil, fmt.Errorf("wkt: unexpected character %q at position %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func isWKTLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isWKTNumber accepts 'e' and 'E' for exponents, a number token never starts with them.
+func isWKTNumber(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E'
+}
+
+type wktParser struct {
+	tokens []string
+	pos    int
+	// dimension is the number of ordinates of each position, 0 when it is not declared
+	dimension int
+}
+
+func (p *wktParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *wktParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *wktParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *wktParser) expect(token string) error {
+	if p.done() {
+		return fmt.Errorf("wkt: expected %q, reached end of input", token)
+	}
+	if t := p.next(); t != token {
+		return fmt.Errorf("wkt: expected %q, got %q", token, t)
+	}
+	return nil
+}
+
+// isEmpty consumes the EMPTY keyword if it is the next token
+func (p *wktParser) isEmpty() bool {
+	if p.peek() == wktEmpty {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) parseGeometry() (Geometry, error) {
+	if p.done() {
+		return nil, errors.New("wkt: reached end of input, expected geometry")
+	}
+	tag := p.next()
+	dimension := 0
+	for _, suffix := range []string{"ZM", "Z", "M"} {
+		if base := strings.TrimSuffix(tag, suffix); base != tag && isWKTTag(base) {
+			tag, dimension = base, 2+len(suffix)
+			break
+		}
+	}
+	switch p.peek() {
+	case "ZM":
+		p.pos++
+		dimension = 4
+	case "Z", "M":
+		p.pos++
+		dimension = 3
+	}
+	p.dimension = dimension
+
+	switch tag {
+	case wktPoint:
+		if p.isEmpty() {
+			return emptyPoint(), nil
+		}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		point, err := p.parsePosition()
+		if err != nil {
+			return nil, err
+		}
+		return point, p.expect(")")
+	case wktMultiPoint:
+		points, err := p.parseMultiPoint()
+		if err != nil {
+			return nil, err
+		}
+		return NewMultiPoint(points), nil
+	case wktLineString:
+		lineString, err := p.parseLineString()
+		if err != nil {
+			return nil, err
+		}
+		return lineString, nil
+	case wktMultiLineString:
+		lineStrings, err := p.parseLineStrings()
+		if err != nil {
+			return nil, err
+		}
+		return NewMultiLineString(lineStrings), nil
+	case wktPolygon:
+		lineStrings, err := p.parseLineStrings()
+		if err != nil {
+			return nil, err
+		}
+		return NewPolygon(lineStrings), nil
+	case wktMultiPolygon:
+		polygons := []*Polygon{}
+		err := p.parseList(func() error {
+			lineStrings, err := p.parseLineStrings()
+			if err != nil {
+				return err
+			}
+			polygons = append(polygons, NewPolygon(lineStrings))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return NewMultiPolygon(polygons), nil
+	case wktGeometryCollection:
+		geometries := []Geometry{}
+		err := p.parseList(func() error {
+			geometry, err := p.parseGeometry()
+			if err != nil {
+				return err
+			}
+			geometries = append(geometries, geometry)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return NewGeometryCollection(geometries), nil
+	}
+	return nil, fmt.Errorf("wkt: unknown geometry type %q", tag)
+}
+
+// parseList parses EMPTY or a parenthesised, comma separated list of elements
+func (p *wktParser) parseList(parseElement func() error) error {
+	if p.isEmpty() {
+		return nil
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if err := parseElement(); err != nil {
+			return err
+		}
+		if p.peek() != "," {
+			break
+		}
+		p.pos++
+	}
+	return p.expect(")")
+}
+
+func (p *wktParser) parsePosition() (*Point, error) {
+	ordinates := []float64{}
+	for !p.done() && p.peek() != "," && p.peek() != ")" {
+		t := p.next()
+		v, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return nil, fmt.Errorf("wkt: invalid number %q", t)
+		}
+		ordinates = append(ordinates, v)
+	}
+	if p.dimension != 0 && len(ordinates) != p.dimension {
+		return nil, fmt.Errorf("wkt: expected %d ordinates, got %d", p.dimension, len(ordinates))
+	}
+	if len(ordinates) < 2 || len(ordinates) > 4 {
+		return nil, fmt.Errorf("wkt: position should have 2 to 4 ordinates, got %d", len(ordinates))
+	}
+	return &Point{ordinates[1], ordinates[0]}, nil
+}
+
+// parseMultiPoint accepts both MULTIPOINT ((1 2), (3 4)) and MULTIPOINT (1 2, 3 4)
+func (p *wktParser) parseMultiPoint() ([]*Point, error) {
+	points := []*Point{}
+	err := p.parseList(func() error {
+		if p.isEmpty() {
+			points = append(points, emptyPoint())
+			return nil
+		}
+		wrapped := p.peek() == "("
+		if wrapped {
+			p.pos++
+		}
+		point, err := p.parsePosition()
+		if err != nil {
+			return err
+		}
+		points = append(points, point)
+		if wrapped {
+			return p.expect(")")
+		}
+		return nil
+	})
+	return points, err
+}
+
+func (p *wktParser) parseLineString() (*LineString, error) {
+	points := []*Point{}
+	err := p.parseList(func() error {
+		point, err := p.parsePosition()
+		if err != nil {
+			return err
+		}
+		points = append(points, point)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewLineString(points), nil
+}
+
+func (p *wktParser) parseLineStrings() ([]*LineString, error) {
+	lineStrings := []*LineString{}
+	err := p.parseList(func() error {
+		lineString, err := p.parseLineString()
+		if err != nil {
+			return err
+		}
+		lineStrings = append(lineStrings, lineString)
+		return nil
+	})
+	return lineStrings, err
+}
+
+// emptyPoint returns a point representing an empty point geometry, both its coordinates are NaN
+func emptyPoint() *Point {
+	return &Point{math.NaN(), math.NaN()}
+}
+
+func isEmptyPoint(point *Point) bool {
+	return math.IsNaN(point.Lat) && math.IsNaN(point.Lng)
+}
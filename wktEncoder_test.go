@@ -0,0 +1,119 @@
+package turfgo
+
+import (
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseWKT(t *testing.T) {
+	Convey("Given wkt fixtures, should parse the same geometries as the geoJson fixtures", t, func() {
+		for _, name := range []string{"point", "multiPoint", "lineString", "multiLineString",
+			"polygon", "multiPolygon", "geometryCollection"} {
+			wkt, _ := ioutil.ReadFile("./testdata/wkt/" + name + ".wkt")
+			gj, _ := ioutil.ReadFile("./testdata/geoJsonEncoder/" + name + ".geojson")
+			g, err := ParseWKT(string(wkt))
+			So(err, ShouldBeNil)
+			expected, _ := DecodeGeometry(gj)
+			So(g, ShouldResemble, expected)
+		}
+	})
+
+	Convey("Given wkt with Z, M and ZM ordinates, should keep only lng and lat", t, func() {
+		expected := NewLineString([]*Point{{2, 1}, {4, 3}})
+		for _, wkt := range []string{
+			"LINESTRING Z (1 2 5, 3 4 6)",
+			"LINESTRING M (1 2 5, 3 4 6)",
+			"LINESTRING ZM (1 2 5 7, 3 4 6 8)",
+			"LINESTRINGZ (1 2 5, 3 4 6)",
+			"LINESTRING (1 2 5, 3 4 6)",
+		} {
+			g, err := ParseWKT(wkt)
+			So(err, ShouldBeNil)
+			So(g, ShouldResemble, expected)
+		}
+	})
+
+	Convey("Given multiPoint without inner parenthesis, should parse points", t, func() {
+		g, err := ParseWKT("MULTIPOINT (1 2, 3 4)")
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, NewMultiPoint([]*Point{{2, 1}, {4, 3}}))
+	})
+
+	Convey("Given empty geometries, should parse them", t, func() {
+		g, err := ParseWKT("POINT EMPTY")
+		So(err, ShouldBeNil)
+		p := g.(*Point)
+		So(math.IsNaN(p.Lat) && math.IsNaN(p.Lng), ShouldBeTrue)
+
+		g, err = ParseWKT("polygon empty")
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, NewPolygon([]*LineString{}))
+
+		g, err = ParseWKT("MULTIPOLYGON Z EMPTY")
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, NewMultiPolygon([]*Polygon{}))
+	})
+
+	Convey("Given lowercase wkt with SRID and exponents, should parse it", t, func() {
+		g, err := ParseWKT("SRID=4326;point(1.5e2 -2E-1)")
+		So(err, ShouldBeNil)
+		So(g, ShouldResemble, &Point{-0.2, 150})
+	})
+
+	Convey("Given invalid wkt, should return error", t, func() {
+		errorCases := map[string]string{
+			"CIRCLE (1 2)":               `wkt: unknown geometry type "CIRCLE"`,
+			"POINT (1 2":                 `wkt: expected ")", reached end of input`,
+			"POINT (1)":                  "wkt: position should have 2 to 4 ordinates, got 1",
+			"POINT Z (1 2)":              "wkt: expected 3 ordinates, got 2",
+			"POINT (1 x)":                `wkt: invalid number "X"`,
+			"POINT (1 2) POINT (1 2)":    `wkt: unexpected token "POINT" after geometry`,
+			"LINESTRING (1 2; 3 4)":      `wkt: unexpected character ';' at position 15`,
+			"POLYGON (1 2, 3 4)":         `wkt: expected "(", got "1"`,
+			"POINT (1 2-)":               `wkt: invalid number "2-"`,
+			"":                           "wkt: reached end of input, expected geometry",
+			"GEOMETRYCOLLECTION (POINT)": `wkt: expected "(", got ")"`,
+		}
+		for wkt, message := range errorCases {
+			g, err := ParseWKT(wkt)
+			So(g, ShouldBeNil)
+			So(err.Error(), ShouldEqual, message)
+		}
+	})
+}
+
+func TestMarshalWKT(t *testing.T) {
+	Convey("Given wkt fixtures, should write back the same wkt", t, func() {
+		files, _ := ioutil.ReadDir("./testdata/wkt")
+		for _, f := range files {
+			j, _ := ioutil.ReadFile("./testdata/wkt/" + f.Name())
+			wkt := strings.TrimSpace(string(j))
+			g, err := ParseWKT(wkt)
+			So(err, ShouldBeNil)
+			result, err := MarshalWKT(g)
+			So(err, ShouldBeNil)
+			So(result, ShouldEqual, wkt)
+		}
+	})
+
+	Convey("Given a feature, should write its geometry", t, func() {
+		feature := NewFeature(NewPoint(2, 1), map[string]interface{}{"name": "depot"})
+		wkt, err := MarshalWKT(feature)
+		So(err, ShouldBeNil)
+		So(wkt, ShouldEqual, "POINT (1 2)")
+
+		fc := NewFeatureCollection([]*Feature{feature, NewFeature(NewLineString(nil), nil)})
+		wkt, err = MarshalWKT(fc)
+		So(err, ShouldBeNil)
+		So(wkt, ShouldEqual, "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING EMPTY)")
+	})
+
+	Convey("Given a feature without geometry, should return error", t, func() {
+		_, err := MarshalWKT(NewFeature(nil, nil))
+		So(err.Error(), ShouldEqual, "wkt: feature has no geometry")
+	})
+}